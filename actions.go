@@ -0,0 +1,157 @@
+package slacker
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/shomali11/proper"
+	"github.com/slack-go/slack"
+)
+
+const (
+	actionIDSeparator = "/"
+	actionWildcard    = "*"
+	actionParamFormat = "([^" + actionIDSeparator + "]+?)"
+	actionAnyPattern  = ".*"
+)
+
+// actionParamExpression finds the <name> placeholders of an action pattern
+var actionParamExpression = regexp.MustCompile(`<[^<>]+>`)
+
+// ActionRequest is a block element a user acted on, routed by its IDs, along with the values parsed from them.
+// Time is set for date and time pickers, in the timezone of the user who acted, as ViewStateValue.TimeIn parses it
+type ActionRequest struct {
+	ActionID         string
+	BlockID          string
+	Value            string
+	SelectedOption   *slack.OptionBlockObject
+	Time             time.Time
+	UserID           string
+	ChannelID        string
+	TeamID           string
+	MessageTimestamp string
+	TriggerID        string
+	properties       *proper.Properties
+}
+
+// Param attempts to look up a string value by key. If not found, return the an empty string
+func (r *ActionRequest) Param(key string) string {
+	return r.StringParam(key, empty)
+}
+
+// StringParam attempts to look up a string value by key. If not found, return the default string value
+func (r *ActionRequest) StringParam(key string, defaultValue string) string {
+	return r.properties.StringParam(key, defaultValue)
+}
+
+// BooleanParam attempts to look up a boolean value by key. If not found, return the default boolean value
+func (r *ActionRequest) BooleanParam(key string, defaultValue bool) bool {
+	return r.properties.BooleanParam(key, defaultValue)
+}
+
+// IntegerParam attempts to look up a integer value by key. If not found, return the default integer value
+func (r *ActionRequest) IntegerParam(key string, defaultValue int) int {
+	return r.properties.IntegerParam(key, defaultValue)
+}
+
+// FloatParam attempts to look up a float value by key. If not found, return the default float value
+func (r *ActionRequest) FloatParam(key string, defaultValue float64) float64 {
+	return r.properties.FloatParam(key, defaultValue)
+}
+
+// actionRoute is a handler for the actions whose IDs match its pattern
+type actionRoute struct {
+	expression *regexp.Regexp
+	params     []string
+	withBlock  bool
+	handler    func(request *ActionRequest, response ResponseWriter)
+}
+
+// OnAction handle the block elements whose action ID matches the pattern, or whose block ID and action ID do
+// when given as "block/action". A * matches anything, while a <name> placeholder matches a value which is
+// available as a parameter, as in "approve_<id>". Routes are tried in the order they were added.
+// Replies go through the interaction's response URL when it has one, so they work even where the bot is not a member.
+// It requires the InteractionsHandler to be served
+func (s *Slacker) OnAction(pattern string, handler func(request *ActionRequest, response ResponseWriter)) {
+	var params []string
+	expression := empty
+	for _, part := range strings.Split(pattern, actionWildcard) {
+		if len(expression) > 0 {
+			expression += actionAnyPattern
+		}
+
+		literals := actionParamExpression.Split(part, -1)
+		placeholders := actionParamExpression.FindAllString(part, -1)
+		for i, literal := range literals {
+			expression += regexp.QuoteMeta(literal)
+			if i < len(placeholders) {
+				params = append(params, placeholders[i][1:len(placeholders[i])-1])
+				expression += actionParamFormat
+			}
+		}
+	}
+
+	s.actionRoutes = append(s.actionRoutes, &actionRoute{
+		expression: regexp.MustCompile("^" + expression + "$"),
+		params:     params,
+		withBlock:  strings.Contains(pattern, actionIDSeparator),
+		handler:    handler,
+	})
+}
+
+// routeAction executes the first route matching the action, returning whether there was one
+func (s *Slacker) routeAction(payload *interaction, action *interactionAction) bool {
+	for _, route := range s.actionRoutes {
+		id := action.ActionID
+		if route.withBlock {
+			id = action.BlockID + actionIDSeparator + action.ActionID
+		}
+
+		matches := route.expression.FindStringSubmatch(id)
+		if matches == nil {
+			continue
+		}
+
+		// Looking up the user's timezone is only worth it for pickers
+		var picked time.Time
+		if len(action.SelectedDate) > 0 || len(action.SelectedTime) > 0 {
+			var err error
+			picked, err = parsePicked(action.SelectedDate, action.SelectedTime, s.userLocation(payload.User.ID))
+			if err != nil {
+				s.reportError(err)
+			}
+		}
+
+		parameters := make(map[string]string)
+		for i, param := range route.params {
+			parameters[param] = matches[i+1]
+		}
+
+		request := &ActionRequest{
+			ActionID:         action.ActionID,
+			BlockID:          action.BlockID,
+			Value:            action.Value,
+			SelectedOption:   action.SelectedOption,
+			Time:             picked,
+			UserID:           payload.User.ID,
+			ChannelID:        payload.Container.ChannelID,
+			TeamID:           payload.Team.ID,
+			MessageTimestamp: payload.Container.MessageTs,
+			TriggerID:        payload.TriggerID,
+			properties:       proper.NewProperties(parameters),
+		}
+		route.handler(request, s.interactionResponse(payload))
+		return true
+	}
+	return false
+}
+
+// interactionResponse creates the response replying to the interaction through its response URL,
+// or else through the Web API in the channel it happened in
+func (s *Slacker) interactionResponse(payload *interaction) ResponseWriter {
+	if len(payload.ResponseURL) > 0 {
+		return newResponseURLResponse(payload.ResponseURL, payload.Container.ChannelID, s)
+	}
+	return newAPIResponse(payload.Container.ChannelID, s)
+}
@@ -0,0 +1,81 @@
+package slacker
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+	formContentType     = "application/x-www-form-urlencoded"
+)
+
+// callAPI posts a JSON payload to a Web API method the slack client does not cover and decodes the result
+func (s *Slacker) callAPI(method string, payload interface{}, result interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.requestAPI(method, jsonContentType, body, result)
+	return err
+}
+
+// queryAPI is like callAPI for methods that only accept form encoded arguments
+func (s *Slacker) queryAPI(method string, values url.Values, result interface{}) error {
+	_, err := s.requestAPI(method, formContentType, []byte(values.Encode()), result)
+	return err
+}
+
+// requestAPI posts the body to a Web API method and decodes the result, also returning the response headers
+func (s *Slacker) requestAPI(method string, contentType string, body []byte, result interface{}) (http.Header, error) {
+	if s.isDryRun() && dryRunMethods[method] {
+		s.defaults.DryRunLogger.Printf(dryRunAPIFormat, method, body)
+		return http.Header{}, nil
+	}
+
+	token, err := s.apiToken()
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, slack.APIURL+method, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set(contentTypeHeader, contentType)
+	request.Header.Set(authorizationHeader, bearerPrefix+token)
+
+	response, err := s.defaults.HTTPClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var raw json.RawMessage
+	err = json.NewDecoder(response.Body).Decode(&raw)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &slack.SlackResponse{}
+	err = json.Unmarshal(raw, status)
+	if err != nil {
+		return nil, err
+	}
+
+	if !status.Ok {
+		return response.Header, errors.New(status.Error)
+	}
+
+	if result == nil {
+		return response.Header, nil
+	}
+	return response.Header, json.Unmarshal(raw, result)
+}
@@ -0,0 +1,49 @@
+package slacker
+
+import (
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	timeLayout = "15:04"
+)
+
+// markdownObject creates a text object formatted using Slack's markdown
+func markdownObject(text string) *slack.TextBlockObject {
+	return slack.NewTextBlockObject(slack.MarkdownType, text, false, false)
+}
+
+// plainTextObject creates a plain text object
+func plainTextObject(text string) *slack.TextBlockObject {
+	return slack.NewTextBlockObject(slack.PlainTextType, text, true, false)
+}
+
+// markdownSection creates a section block displaying the markdown text
+func markdownSection(text string) *slack.SectionBlock {
+	return slack.NewSectionBlock(markdownObject(text), nil, nil)
+}
+
+// markdownContext creates a context block displaying the markdown text
+func markdownContext(text string) *slack.ContextBlock {
+	return slack.NewContextBlock(empty, markdownObject(text))
+}
+
+// parsePicked parses the date and time picked with picker elements in the location, returning the zero time
+// if nothing was picked. A time alone is on the current date in the location, a date alone at midnight
+func parsePicked(date string, clock string, location *time.Location) (time.Time, error) {
+	if len(date) == 0 && len(clock) == 0 {
+		return time.Time{}, nil
+	}
+
+	if len(date) == 0 {
+		date = time.Now().In(location).Format(dateLayout)
+	}
+
+	if len(clock) == 0 {
+		return time.ParseInLocation(dateLayout, date, location)
+	}
+	return time.ParseInLocation(dateLayout+space+timeLayout, date+space+clock, location)
+}
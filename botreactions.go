@@ -0,0 +1,121 @@
+package slacker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	botMessageTTL     = 24 * time.Hour
+	commandNotAllowed = "command not allowed"
+)
+
+// BotMessageReaction is a reaction added to a message the bot posted in reply to a command
+type BotMessageReaction struct {
+	Reaction  string
+	UserID    string
+	Channel   string
+	Timestamp string
+	Command   string
+	Event     *slack.MessageEvent
+}
+
+// botMessage is a message the bot posted in reply to the command of the event
+type botMessage struct {
+	command string
+	event   *slack.MessageEvent
+	expiry  time.Time
+}
+
+// postTracker is implemented by the responses that can report the timestamps of the messages they post.
+// trackPosts adds the callback to the ones already called, returning a function removing it,
+// or false when the response cannot report timestamps after all
+type postTracker interface {
+	trackPosts(posted func(timestamp string)) (untrack func(), ok bool)
+}
+
+// OnReactionToBotMessage handle the reaction being added to a message the bot posted in reply to a command,
+// such as re-running the command with RerunCommand. Replies are remembered for a day, in memory,
+// and only when posted through the Web API, as replies sent over the connection have no timestamp
+func (s *Slacker) OnReactionToBotMessage(reaction string, handler func(reaction *BotMessageReaction)) {
+	s.botMessagesMutex.Lock()
+	defer s.botMessagesMutex.Unlock()
+
+	s.botMessageReactions[reaction] = handler
+}
+
+// RerunCommand matches the message that the reacted message replied to against the commands again, as sent by the user who reacted.
+// It fails with ErrCommandNotAllowed when the user who reacted is not allowed to use the command,
+// internal-only commands only being re-run by the user who first ran them
+func (s *Slacker) RerunCommand(ctx context.Context, reaction *BotMessageReaction) error {
+	reacted := *reaction.Event
+	reacted.User = reaction.UserID
+	for _, command := range s.botCommands {
+		if command.usage != reaction.Command {
+			continue
+		}
+
+		if !s.isCommandAllowed(command, &reacted) || (command.internalOnly && reaction.UserID != reaction.Event.User) {
+			return ErrCommandNotAllowed
+		}
+	}
+
+	response := s.newResponse(&reacted, newAPIResponse(reacted.Channel, s))
+	return s.handleMessage(withReplay(ctx), &reacted, response)
+}
+
+// trackReplies remembers the messages posted in reply to the command of the event, when reactions to them are handled
+func (s *Slacker) trackReplies(command *BotCommand, event *slack.MessageEvent, response ResponseWriter) {
+	s.botMessagesMutex.Lock()
+	handled := len(s.botMessageReactions) > 0
+	s.botMessagesMutex.Unlock()
+
+	tracker, ok := response.(postTracker)
+	if !handled || !ok {
+		return
+	}
+
+	tracker.trackPosts(func(timestamp string) {
+		s.addBotMessage(event.Channel, timestamp, &botMessage{command: command.usage, event: event})
+	})
+}
+
+func (s *Slacker) addBotMessage(channel string, timestamp string, message *botMessage) {
+	s.botMessagesMutex.Lock()
+	defer s.botMessagesMutex.Unlock()
+
+	now := time.Now()
+	for key, message := range s.botMessages {
+		if now.After(message.expiry) {
+			delete(s.botMessages, key)
+		}
+	}
+
+	message.expiry = now.Add(botMessageTTL)
+	s.botMessages[fmt.Sprintf(messageKeyFormat, channel, timestamp)] = message
+}
+
+// handleBotMessageReaction returns whether the reaction to one of the bot's replies was handled
+func (s *Slacker) handleBotMessageReaction(event *slack.ReactionAddedEvent) bool {
+	s.botMessagesMutex.Lock()
+	handler, ok := s.botMessageReactions[event.Reaction]
+	message, posted := s.botMessages[fmt.Sprintf(messageKeyFormat, event.Item.Channel, event.Item.Timestamp)]
+	s.botMessagesMutex.Unlock()
+
+	if !ok || !posted || time.Now().After(message.expiry) || event.User == s.botUserID() {
+		return false
+	}
+
+	handler(&BotMessageReaction{
+		Reaction:  event.Reaction,
+		UserID:    event.User,
+		Channel:   event.Item.Channel,
+		Timestamp: event.Item.Timestamp,
+		Command:   message.command,
+		Event:     message.event,
+	})
+	return true
+}
@@ -0,0 +1,131 @@
+package slacker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	defaultBroadcastInterval = time.Second
+	broadcastErrorFormat     = "failed to deliver to %d of %d recipients: %s"
+	broadcastFailureFormat   = "%s: %s"
+	broadcastFailureSep      = ", "
+)
+
+// BroadcastOption an option for broadcast values
+type BroadcastOption func(*BroadcastDefaults)
+
+// WithBroadcastInterval sets the pause between two messages of a broadcast, to stay within rate limits
+func WithBroadcastInterval(interval time.Duration) BroadcastOption {
+	return func(defaults *BroadcastDefaults) {
+		defaults.Interval = interval
+	}
+}
+
+// WithBroadcastParams sets the parameters the messages of a broadcast are posted with
+func WithBroadcastParams(params slack.PostMessageParameters) BroadcastOption {
+	return func(defaults *BroadcastDefaults) {
+		defaults.Params = params
+	}
+}
+
+// BroadcastDefaults configuration
+type BroadcastDefaults struct {
+	Interval time.Duration
+	Params   slack.PostMessageParameters
+}
+
+func newBroadcastDefaults(options ...BroadcastOption) *BroadcastDefaults {
+	config := &BroadcastDefaults{
+		Interval: defaultBroadcastInterval,
+		Params:   slack.NewPostMessageParameters(),
+	}
+
+	for _, option := range options {
+		option(config)
+	}
+	return config
+}
+
+// BroadcastError reports the recipients a broadcast could not be delivered to
+type BroadcastError struct {
+	// Recipients is the number of recipients of the broadcast
+	Recipients int
+
+	// Failures contains the error of every failed recipient, by ID
+	Failures map[string]error
+}
+
+// Error lists the failed recipients along with their errors
+func (e *BroadcastError) Error() string {
+	var recipients []string
+	for recipient := range e.Failures {
+		recipients = append(recipients, recipient)
+	}
+	sort.Strings(recipients)
+
+	failures := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		failures = append(failures, fmt.Sprintf(broadcastFailureFormat, recipient, e.Failures[recipient]))
+	}
+	return fmt.Sprintf(broadcastErrorFormat, len(e.Failures), e.Recipients, strings.Join(failures, broadcastFailureSep))
+}
+
+// BroadcastDM sends the text to each of the users in a direct message, opening the conversations as needed.
+// Messages are paced and retried when rate limited. If any user could not be reached, a *BroadcastError is returned,
+// failing those left with ErrClosed once the bot is closed
+func (s *Slacker) BroadcastDM(userIDs []string, text string, options ...BroadcastOption) error {
+	defaults := newBroadcastDefaults(options...)
+	return s.broadcast(userIDs, defaults.Interval, func(userID string) error {
+		channel, err := s.directChannel(userID)
+		if err != nil {
+			return err
+		}
+		_, err = s.postWithRetries(channel, text, slack.MsgOptionPostMessageParameters(defaults.Params))
+		return err
+	})
+}
+
+// Broadcast posts the text to each of the channels, as incident bots notify the teams involved.
+// Messages are paced and retried when rate limited. If any channel could not be reached, a *BroadcastError is returned,
+// failing those left with ErrClosed once the bot is closed
+func (s *Slacker) Broadcast(channels []string, text string, options ...BroadcastOption) error {
+	defaults := newBroadcastDefaults(options...)
+	return s.broadcast(channels, defaults.Interval, func(channel string) error {
+		_, err := s.postWithRetries(channel, text, slack.MsgOptionPostMessageParameters(defaults.Params))
+		return err
+	})
+}
+
+// broadcast delivers to each recipient in turn, pausing in between, and collects the failures.
+// Once the bot is closed, the recipients left fail with ErrClosed
+func (s *Slacker) broadcast(recipients []string, interval time.Duration, deliver func(recipient string) error) error {
+	failures := make(map[string]error)
+	for i, recipient := range recipients {
+		if i > 0 && interval > 0 {
+			select {
+			case <-time.After(interval):
+			case <-s.done:
+			}
+		}
+
+		if s.isClosed() {
+			failures[recipient] = ErrClosed
+			continue
+		}
+
+		err := deliver(recipient)
+		if err != nil {
+			failures[recipient] = err
+		}
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+	return &BroadcastError{Recipients: len(recipients), Failures: failures}
+}
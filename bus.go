@@ -0,0 +1,79 @@
+package slacker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	// MessageReceivedEvent is published when a message addressed to the bot is about to be matched against the commands
+	MessageReceivedEvent = "message.received"
+
+	// CommandMatchedEvent is published when a message matched a command, before it is executed
+	CommandMatchedEvent = "command.matched"
+
+	// ReplySentEvent is published when a command replies
+	ReplySentEvent = "reply.sent"
+
+	// CommandFailedEvent is published when a command reported an error or panicked
+	CommandFailedEvent = "command.failed"
+
+	// CommandExecutedEvent is published when a command finished, whether it failed or not
+	CommandExecutedEvent = "command.executed"
+)
+
+const (
+	subscriberPanicFormat = "subscriber to %s panicked: %v"
+)
+
+// BusEvent is a lifecycle event of the bot, published to its subscribers
+type BusEvent struct {
+	Name      string
+	Time      time.Time
+	Event     *slack.MessageEvent
+	Command   string
+	Text      string
+	Error     error
+	RequestID string
+}
+
+// Subscribe calls the handler whenever an event with the name is published. Handlers are called synchronously
+// by the goroutine handling the message, so they should return quickly. A panicking handler is reported as an error
+func (s *Slacker) Subscribe(name string, handler func(event *BusEvent)) {
+	s.subscribersMutex.Lock()
+	defer s.subscribersMutex.Unlock()
+
+	if s.subscribers == nil {
+		s.subscribers = make(map[string][]func(event *BusEvent))
+	}
+	s.subscribers[name] = append(s.subscribers[name], handler)
+}
+
+// publish calls the handlers subscribed to the event's name
+func (s *Slacker) publish(event *BusEvent) {
+	s.subscribersMutex.Lock()
+	handlers := s.subscribers[event.Name]
+	s.subscribersMutex.Unlock()
+
+	if len(handlers) == 0 {
+		return
+	}
+
+	event.Time = time.Now()
+	for _, handler := range handlers {
+		s.notify(handler, event)
+	}
+}
+
+// notify calls the subscribed handler, recovering from its panic so the other handlers are still called
+func (s *Slacker) notify(handler func(event *BusEvent), event *BusEvent) {
+	defer func() {
+		recovered := recover()
+		if recovered != nil {
+			s.reportError(withRequestIDError(fmt.Errorf(subscriberPanicFormat, event.Name, recovered), event.RequestID))
+		}
+	}()
+	handler(event)
+}
@@ -0,0 +1,56 @@
+package slacker
+
+import (
+	"sync"
+	"time"
+)
+
+// newTTLCache creates a cache that remembers keys for the given duration
+func newTTLCache(ttl time.Duration) *ttlCache {
+	return &ttlCache{ttl: ttl, entries: make(map[string]time.Time)}
+}
+
+// ttlCache records keys and reports whether they were seen within the TTL
+type ttlCache struct {
+	mutex   sync.Mutex
+	ttl     time.Duration
+	entries map[string]time.Time
+}
+
+// Seen records the key and returns whether it had already been recorded within the TTL
+func (c *ttlCache) Seen(key string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	for entry, expiry := range c.entries {
+		if now.After(expiry) {
+			delete(c.entries, entry)
+		}
+	}
+
+	_, ok := c.entries[key]
+	if !ok {
+		c.entries[key] = now.Add(c.ttl)
+	}
+	return ok
+}
+
+// Has returns whether the key was recorded within the TTL, without recording it
+func (c *ttlCache) Has(key string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	expiry, ok := c.entries[key]
+	return ok && time.Now().Before(expiry)
+}
+
+// Forget removes the key and returns whether it had been recorded within the TTL
+func (c *ttlCache) Forget(key string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	expiry, ok := c.entries[key]
+	delete(c.entries, key)
+	return ok && time.Now().Before(expiry)
+}
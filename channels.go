@@ -0,0 +1,153 @@
+package slacker
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	conversationsInfoMethod = "conversations.info"
+	channelField            = "channel"
+	includeNumMembersField  = "include_num_members"
+	channelCacheTTL         = time.Hour
+	channelFailureTTL       = 5 * time.Minute
+	trueValue               = "true"
+)
+
+// Channel contains the metadata of a channel, direct message or group direct message
+type Channel struct {
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	Topic       slack.Topic   `json:"topic"`
+	Purpose     slack.Purpose `json:"purpose"`
+	NumMembers  int           `json:"num_members"`
+	Private     bool          `json:"is_private"`
+	IsIM        bool          `json:"is_im"`
+	IsMpIM      bool          `json:"is_mpim"`
+	IsArchived  bool          `json:"is_archived"`
+	IsExtShared bool          `json:"is_ext_shared"`
+	expiry      time.Time
+}
+
+// IsPrivate determines whether the channel is only visible to its members, as private channels and direct messages are
+func (c *Channel) IsPrivate() bool {
+	return c.Private || c.IsIM || c.IsMpIM
+}
+
+// conversationInfoResponse is the conversations.info response
+type conversationInfoResponse struct {
+	Channel *Channel `json:"channel"`
+}
+
+// conversationInfo returns the channel's metadata, asking the Web API when the channel was not seen within the hour
+func (s *Slacker) conversationInfo(channelID string) (*Channel, error) {
+	s.conversationsMutex.Lock()
+	info, ok := s.conversations[channelID]
+	s.conversationsMutex.Unlock()
+	if ok && time.Now().Before(info.expiry) {
+		return info, nil
+	}
+
+	response := &conversationInfoResponse{}
+	err := s.queryAPI(conversationsInfoMethod, url.Values{channelField: {channelID}, includeNumMembersField: {trueValue}}, response)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Channel.expiry = time.Now().Add(channelCacheTTL)
+	s.conversationsMutex.Lock()
+	s.conversations[channelID] = response.Channel
+	s.conversationsMutex.Unlock()
+	return response.Channel, nil
+}
+
+// Channel returns the metadata of the channel the message was sent in, such as its name, topic and member count.
+// Channels are cached for an hour
+func (r *Request) Channel() (*Channel, error) {
+	if r.slacker == nil {
+		return nil, ErrNoBotClient
+	}
+	return r.slacker.conversationInfo(r.Event.Channel)
+}
+
+// channelName returns the channel's name, or empty if it could not be found.
+// Channels failing to be looked up are not looked up again for a few minutes
+func (s *Slacker) channelName(channelID string) string {
+	if s.failedChannels.Has(channelID) {
+		return empty
+	}
+
+	info, err := s.conversationInfo(channelID)
+	if err != nil {
+		if !s.failedChannels.Seen(channelID) {
+			s.reportError(err)
+		}
+		return empty
+	}
+	return info.Name
+}
+
+// directChannel opens the direct message channel with the user, returning its ID
+func (s *Slacker) directChannel(userID string) (string, error) {
+	channel, _, _, err := s.APIClient().OpenConversation(&slack.OpenConversationParameters{Users: []string{userID}})
+	if err != nil {
+		return empty, err
+	}
+	return channel.ID, nil
+}
+
+// isCommandAllowed determines whether the command can be used by the user, in the workspace, channel and thread of the event
+func (s *Slacker) isCommandAllowed(command *BotCommand, event *slack.MessageEvent) bool {
+	if !command.isInScope(event) {
+		return false
+	}
+
+	if len(command.teams) > 0 && !command.isInTeam(event.Team) {
+		return false
+	}
+
+	if len(command.enterprises) > 0 && !command.isInEnterprise(s.EnterpriseID(event.Team)) {
+		return false
+	}
+
+	if command.adminOnly && !s.isAdmin(event.User) {
+		return false
+	}
+
+	if len(command.channels) == 0 {
+		return true
+	}
+	if command.isInChannel(event.Channel) {
+		return true
+	}
+
+	name := s.channelName(event.Channel)
+	return len(name) > 0 && command.isInChannel(name)
+}
+
+// isAmbient determines whether the event's channel is one where the bot handles messages without being mentioned
+func (s *Slacker) isAmbient(event *slack.MessageEvent) bool {
+	if len(s.defaults.AmbientChannels) == 0 {
+		return false
+	}
+
+	for _, channel := range s.defaults.AmbientChannels {
+		if channel == event.Channel {
+			return true
+		}
+	}
+
+	name := s.channelName(event.Channel)
+	if len(name) == 0 {
+		return false
+	}
+
+	for _, channel := range s.defaults.AmbientChannels {
+		if channel == name {
+			return true
+		}
+	}
+	return false
+}
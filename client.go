@@ -0,0 +1,35 @@
+package slacker
+
+import (
+	"github.com/slack-go/slack"
+)
+
+// MessageSender sends messages over the Real-Time Messaging connection, as *slack.RTM does
+type MessageSender interface {
+	SendMessage(message *slack.OutgoingMessage)
+	NewOutgoingMessage(text string, channelID string, options ...slack.RTMsgOption) *slack.OutgoingMessage
+	NewTypingMessage(channelID string) *slack.OutgoingMessage
+}
+
+// InfoProvider provides the details of the connected bot, as *slack.RTM does
+type InfoProvider interface {
+	GetInfo() *slack.Info
+}
+
+// MessagePoster posts messages through the Web API, as *slack.Client does
+type MessagePoster interface {
+	PostMessage(channel string, options ...slack.MsgOption) (string, string, error)
+	PostEphemeral(channel string, userID string, options ...slack.MsgOption) (string, error)
+}
+
+// Authenticator identifies the bot the token belongs to, as *slack.Client does
+type Authenticator interface {
+	AuthTest() (*slack.AuthTestResponse, error)
+}
+
+// ScopedAuthenticator is an Authenticator also reporting the scopes granted to the token, as auth.test does
+// in its headers, so that ValidateToken can check the required scopes
+type ScopedAuthenticator interface {
+	Authenticator
+	AuthTestScopes() (*slack.AuthTestResponse, []string, error)
+}
@@ -1,27 +1,126 @@
 package slacker
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/shomali11/commander"
 	"github.com/shomali11/proper"
+	"github.com/shomali11/slacker/format"
+)
+
+const (
+	channelPrefix            = "#"
+	deprecatedFormat         = "%s is deprecated"
+	deprecatedReplacementFmt = "%s is deprecated, use %s instead"
 )
 
+// CommandOption an option for command values
+type CommandOption func(*BotCommand)
+
+// WithChannels restricts the command to the channels, given by name or ID
+func WithChannels(channels ...string) CommandOption {
+	return func(command *BotCommand) {
+		for _, channel := range channels {
+			command.channels = append(command.channels, strings.TrimPrefix(channel, channelPrefix))
+		}
+	}
+}
+
+// WithTeams restricts the command to the workspaces with the team IDs
+func WithTeams(teamIDs ...string) CommandOption {
+	return func(command *BotCommand) {
+		command.teams = append(command.teams, teamIDs...)
+	}
+}
+
+// WithAdminOnly restricts the command to the bot's admins
+func WithAdminOnly() CommandOption {
+	return func(command *BotCommand) {
+		command.adminOnly = true
+	}
+}
+
+// WithExamples sets examples of using the command, for documentation and help
+func WithExamples(examples ...string) CommandOption {
+	return func(command *BotCommand) {
+		command.examples = append(command.examples, examples...)
+	}
+}
+
+// WithCategory sets the category the command is grouped under in documentation and help
+func WithCategory(category string) CommandOption {
+	return func(command *BotCommand) {
+		command.category = category
+	}
+}
+
+// WithDeprecated marks the command as deprecated in favor of the replacement, which may be empty.
+// The command still executes, after replying with a deprecation warning
+func WithDeprecated(replacement string) CommandOption {
+	return func(command *BotCommand) {
+		command.deprecated = true
+		command.replacement = replacement
+	}
+}
+
+// WithCommandBotMessages sets whether messages from other bots and workflows can run the command,
+// overriding WithBotMessages
+func WithCommandBotMessages(allowed bool) CommandOption {
+	return func(command *BotCommand) {
+		command.botMessages = &allowed
+	}
+}
+
+// WithHelp sets the help of the command, replacing the generated usage line,
+// so complex commands can document their flags and examples
+func WithHelp(help string) CommandOption {
+	return func(command *BotCommand) {
+		command.help = help
+	}
+}
+
 // NewBotCommand creates a new bot command object
-func NewBotCommand(usage string, description string, handler func(request *Request, response ResponseWriter)) *BotCommand {
-	command := commander.NewCommand(usage)
-	return &BotCommand{usage: usage, description: description, handler: handler, command: command}
+func NewBotCommand(usage string, description string, handler func(request *Request, response ResponseWriter), options ...CommandOption) *BotCommand {
+	pattern, params := parseUsage(usage)
+	command := commander.NewCommand(pattern)
+	botCommand := &BotCommand{usage: usage, description: description, handler: handler, command: command, params: params}
+	for _, option := range options {
+		option(botCommand)
+	}
+	return botCommand
 }
 
 // BotCommand structure contains the bot's command, description and handler
 type BotCommand struct {
-	usage       string
-	description string
-	handler     func(request *Request, response ResponseWriter)
-	command     *commander.Command
+	usage             string
+	description       string
+	handler           func(request *Request, response ResponseWriter)
+	command           *commander.Command
+	channels          []string
+	teams             []string
+	enterprises       []string
+	adminOnly         bool
+	internalOnly      bool
+	hidden            bool
+	examples          []string
+	category          string
+	deprecated        bool
+	replacement       string
+	botMessages       *bool
+	threadScope       threadScope
+	help              string
+	validators        map[string][]Validator
+	params            *paramDeclarations
+	paramDescriptions map[string]string
+	translations      []*translation
 }
 
-// Match determines whether the bot should respond based on the text received
+// Match determines whether the bot should respond based on the text received.
+// Parameters declared as <name!> must be given, and those declared with allowed values must be given one of them
 func (c *BotCommand) Match(text string) (*proper.Properties, bool) {
-	return c.command.Match(text)
+	parameters, isMatch, _ := c.match(text, nil)
+	return parameters, isMatch
 }
 
 // Tokenize returns the command format's tokens
@@ -33,3 +132,80 @@ func (c *BotCommand) Tokenize() []*commander.Token {
 func (c *BotCommand) Execute(request *Request, response ResponseWriter) {
 	c.handler(request, response)
 }
+
+// deprecationWarning returns the warning replied before executing the command, or empty if it is not deprecated
+func (c *BotCommand) deprecationWarning() string {
+	if !c.deprecated {
+		return empty
+	}
+
+	if len(c.replacement) == 0 {
+		return format.Italic(fmt.Sprintf(deprecatedFormat, format.Code(c.usage)))
+	}
+	return format.Italic(fmt.Sprintf(deprecatedReplacementFmt, format.Code(c.usage), format.Code(c.replacement)))
+}
+
+// isInChannel determines whether the channel, given by name or ID, is one the command is restricted to
+func (c *BotCommand) isInChannel(channel string) bool {
+	for _, allowed := range c.channels {
+		if allowed == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// isInTeam determines whether the team ID is one the command is restricted to
+func (c *BotCommand) isInTeam(teamID string) bool {
+	for _, allowed := range c.teams {
+		if allowed == teamID {
+			return true
+		}
+	}
+	return false
+}
+
+// helpText returns the custom help of the command, or its usage line with the parameters highlighted and its description,
+// followed by the descriptions of the parameters and the translations
+func (c *BotCommand) helpText() string {
+	if len(c.help) > 0 {
+		return c.help
+	}
+	return c.usageLine(c.Tokenize(), c.description) + c.paramsHelp() + c.translationsHelp()
+}
+
+// helpDetails returns the help of the command followed by its examples
+func (c *BotCommand) helpDetails() string {
+	details := c.helpText()
+	if len(c.examples) > 0 {
+		details += newLine + helpExamplesHeading
+		for _, example := range c.examples {
+			details += newLine + format.Code(example)
+		}
+	}
+	return details
+}
+
+// isNamed determines whether the words start the keywords the command's usage begins with
+func (c *BotCommand) isNamed(words []string, caseSensitive bool) bool {
+	tokens := c.Tokenize()
+	for i, word := range words {
+		if i >= len(tokens) || tokens[i].IsParameter || !equalWords(word, tokens[i].Word, caseSensitive) {
+			return false
+		}
+	}
+	return len(words) > 0
+}
+
+// usageLine returns the usage with the parameters highlighted, followed by the description
+func (c *BotCommand) usageLine(tokens []*commander.Token, description string) string {
+	text := empty
+	for _, token := range tokens {
+		if token.IsParameter {
+			text += format.Code(c.paramLabel(token.Word)) + space
+		} else {
+			text += format.Bold(token.Word) + space
+		}
+	}
+	return text + dash + space + format.Italic(description)
+}
@@ -0,0 +1,106 @@
+package slacker
+
+import (
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// ConnectionStatus describes the Real-Time Messaging connection when it is established or lost
+type ConnectionStatus struct {
+	// ConnectionCount is the number of times the bot connected, including this time if it just did
+	ConnectionCount int
+
+	// Attempts is the number of attempts it took to connect, or made so far if still disconnected
+	Attempts int
+
+	// LastError is the error the last failed attempt failed with, if any
+	LastError error
+
+	// Intentional is whether the connection was closed on purpose, when disconnected
+	Intentional bool
+}
+
+// connectionTracker follows the attempts made to connect, it is only used by the goroutine listening to the connection
+type connectionTracker struct {
+	connectionCount int
+	attempts        int
+	lastError       error
+}
+
+// OnConnected handle the connection being established, with the attempts it took, for example to alert on flapping
+func (s *Slacker) OnConnected(connectedHandler func(status *ConnectionStatus)) {
+	s.connectedHandler = connectedHandler
+}
+
+// OnDisconnected handle the connection being lost
+func (s *Slacker) OnDisconnected(disconnectedHandler func(status *ConnectionStatus)) {
+	s.disconnectedHandler = disconnectedHandler
+}
+
+// OnLatency handle the round-trip latency of the connection, measured with every ping, for example to chart its health
+func (s *Slacker) OnLatency(latencyHandler func(latency time.Duration)) {
+	s.latencyHandler = latencyHandler
+}
+
+// Latency returns the round-trip latency of the connection last measured, or zero if it has not been measured yet
+func (s *Slacker) Latency() time.Duration {
+	latency, _ := s.latency.Load().(time.Duration)
+	return latency
+}
+
+// trackLatency records the latency and calls the latency handler
+func (s *Slacker) trackLatency(report *slack.LatencyReport) {
+	s.latency.Store(report.Value)
+
+	if s.latencyHandler == nil {
+		return
+	}
+	go s.latencyHandler(report.Value)
+}
+
+// trackConnecting records an attempt to connect
+func (s *Slacker) trackConnecting(event *slack.ConnectingEvent) {
+	s.connection.attempts = event.Attempt
+}
+
+// trackConnectionError records an attempt that failed and the error it failed with, returning the attempts made so far
+func (s *Slacker) trackConnectionError(event *slack.ConnectionErrorEvent) int {
+	// The slack package counts failed attempts from zero
+	s.connection.attempts = event.Attempt + 1
+	s.connection.lastError = event.ErrorObj
+	return s.connection.attempts
+}
+
+// trackConnected calls the connected handler with the attempts it took and starts counting again
+func (s *Slacker) trackConnected(event *slack.ConnectedEvent) {
+	s.connection.connectionCount = event.ConnectionCount
+	status := &ConnectionStatus{
+		ConnectionCount: event.ConnectionCount,
+		Attempts:        s.connection.attempts,
+		LastError:       s.connection.lastError,
+	}
+
+	s.connection.attempts = 0
+	s.connection.lastError = nil
+
+	if s.connectedHandler == nil {
+		return
+	}
+	go s.connectedHandler(status)
+}
+
+// trackDisconnected calls the disconnected handler
+func (s *Slacker) trackDisconnected(event *slack.DisconnectedEvent) {
+	if s.disconnectedHandler == nil {
+		return
+	}
+
+	status := &ConnectionStatus{
+		ConnectionCount: s.connection.connectionCount,
+		Attempts:        s.connection.attempts,
+		LastError:       s.connection.lastError,
+		Intentional:     event.Intentional,
+	}
+	go s.disconnectedHandler(status)
+}
@@ -0,0 +1,277 @@
+package slacker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	deadLetterLimit        = 100
+	panicFormat            = "command panicked: %v"
+	commandPanicked        = "the command failed unexpectedly"
+	deadLetterFormat       = "*#%d* %s - _%s_ (%d attempts)"
+	deadLetterNotFound     = "dead letter not found"
+	noDeadLetters          = "No failed commands"
+	deadLetterReplayed     = "Replayed"
+	deadLettersCommand     = "deadletters"
+	deadLettersDescription = "List the messages whose commands failed"
+	replayCommand          = "deadletters replay <id>"
+	replayDescription      = "Replay the message of a failed command"
+	idParameter            = "id"
+	messageKeyFormat       = "%s:%s"
+)
+
+// DeadLetter is a message whose command panicked or reported an error marked with Failure
+type DeadLetter struct {
+	ID        int
+	Command   string
+	Event     *slack.MessageEvent
+	Error     string
+	Attempts  int
+	FailedAt  time.Time
+	RequestID string
+}
+
+// failure is a reported error marking the command as failed
+type failure struct {
+	error
+}
+
+// Unwrap returns the error marked as a failure
+func (f *failure) Unwrap() error {
+	return f.error
+}
+
+// Failure marks an error reported with ReportError as a failure of the command, whose message is then kept
+// as a dead letter for admins to replay. Other reported errors, such as replies to invalid input, are not
+func Failure(err error) error {
+	return &failure{error: err}
+}
+
+// errorRecorder is a ResponseWriter that remembers the last error reported, and whether it was a failure, and publishes replies
+type errorRecorder struct {
+	ResponseWriter
+	err       error
+	failed    bool
+	slacker   *Slacker
+	command   *BotCommand
+	event     *slack.MessageEvent
+	requestID string
+}
+
+// Reply publishes the reply and sends it
+func (r *errorRecorder) Reply(text string, options ...ReplyOption) {
+	r.slacker.publish(&BusEvent{Name: ReplySentEvent, Event: r.event, Command: r.command.usage, Text: text, RequestID: r.requestID})
+	r.ResponseWriter.Reply(text, options...)
+}
+
+// richErrorRecorder is an errorRecorder wrapping a RichResponseWriter, so handlers can still detect it
+type richErrorRecorder struct {
+	*errorRecorder
+	rich RichResponseWriter
+}
+
+// ReplyTemporary publishes the reply and sends it
+func (r *richErrorRecorder) ReplyTemporary(text string, ttl time.Duration, options ...ReplyOption) error {
+	r.slacker.publish(&BusEvent{Name: ReplySentEvent, Event: r.event, Command: r.command.usage, Text: text, RequestID: r.requestID})
+	return r.rich.ReplyTemporary(text, ttl, options...)
+}
+
+// ReplyTable publishes the table and sends it
+func (r *richErrorRecorder) ReplyTable(headers []string, rows [][]string, options ...ReplyOption) error {
+	r.slacker.publish(&BusEvent{Name: ReplySentEvent, Event: r.event, Command: r.command.usage, Text: renderTable(headers, rows), RequestID: r.requestID})
+	return r.rich.ReplyTable(headers, rows, options...)
+}
+
+// ReplyImage publishes the caption and shares the image
+func (r *richErrorRecorder) ReplyImage(name string, png io.Reader, caption string, options ...ReplyOption) error {
+	r.slacker.publish(&BusEvent{Name: ReplySentEvent, Event: r.event, Command: r.command.usage, Text: caption, RequestID: r.requestID})
+	return r.rich.ReplyImage(name, png, caption, options...)
+}
+
+// Permalink returns the link from the wrapped response
+func (r *richErrorRecorder) Permalink(timestamp string) (string, error) {
+	return r.rich.Permalink(timestamp)
+}
+
+// supportsOptions determines whether the reply options are honored by the response
+func (r *errorRecorder) supportsOptions() bool {
+	limited, ok := r.ResponseWriter.(optionsLimiter)
+	return !ok || limited.supportsOptions()
+}
+
+// trackPosts also reports the timestamps of the replies posted from now on to the callback, if the response can
+func (r *errorRecorder) trackPosts(posted func(timestamp string)) (func(), bool) {
+	tracker, ok := r.ResponseWriter.(postTracker)
+	if !ok {
+		return nil, false
+	}
+	return tracker.trackPosts(posted)
+}
+
+// ReportError remembers the error and reports it, along with the request ID if enabled
+func (r *errorRecorder) ReportError(err error) {
+	r.err = err
+
+	var marked *failure
+	if errors.As(err, &marked) {
+		r.failed = true
+	}
+
+	if r.slacker.defaults.RequestIDInErrors {
+		err = withRequestIDError(err, r.requestID)
+	}
+	r.ResponseWriter.ReportError(err)
+}
+
+// DeadLetters returns the messages whose commands failed, oldest first
+func (s *Slacker) DeadLetters() []*DeadLetter {
+	s.deadLettersMutex.Lock()
+	defer s.deadLettersMutex.Unlock()
+
+	deadLetters := make([]*DeadLetter, len(s.deadLetters))
+	copy(deadLetters, s.deadLetters)
+	return deadLetters
+}
+
+// ReplayDeadLetter handles the message of a dead letter again, replying through the Web API.
+// The dead letter is removed if the command succeeds
+func (s *Slacker) ReplayDeadLetter(ctx context.Context, id int) error {
+	deadLetter := s.findDeadLetter(id)
+	if deadLetter == nil {
+		return ErrDeadLetterNotFound
+	}
+
+	response := s.newResponse(deadLetter.Event, newAPIResponse(deadLetter.Event.Channel, s))
+	err := s.handleMessage(withReplay(ctx), deadLetter.Event, response)
+	if err != nil {
+		return err
+	}
+
+	s.removeDeadLetter(id)
+	return nil
+}
+
+// DeadLetterCommands define admin commands to list and replay the messages whose commands failed
+func (s *Slacker) DeadLetterCommands() {
+	s.Command(replayCommand, replayDescription, func(request *Request, response ResponseWriter) {
+		err := s.ReplayDeadLetter(request.Context, request.IntegerParam(idParameter, 0))
+		if err != nil {
+			response.ReportError(err)
+			return
+		}
+		response.Reply(deadLetterReplayed)
+	}, WithAdminOnly())
+
+	s.Command(deadLettersCommand, deadLettersDescription, func(request *Request, response ResponseWriter) {
+		deadLetters := s.DeadLetters()
+		if len(deadLetters) == 0 {
+			response.Reply(noDeadLetters)
+			return
+		}
+
+		message := empty
+		for _, deadLetter := range deadLetters {
+			message += fmt.Sprintf(deadLetterFormat, deadLetter.ID, deadLetter.Command, deadLetter.Error, deadLetter.Attempts) + newLine
+		}
+		response.Reply(message)
+	}, WithAdminOnly())
+}
+
+// executeCommand executes the command, recording its message as a dead letter if it fails
+func (s *Slacker) executeCommand(cmd *BotCommand, request *Request, response ResponseWriter) (err error) {
+	recorder := &errorRecorder{ResponseWriter: response, slacker: s, command: cmd, event: request.Event, requestID: request.ID()}
+	var writer ResponseWriter = recorder
+	if rich, ok := response.(RichResponseWriter); ok {
+		writer = &richErrorRecorder{errorRecorder: recorder, rich: rich}
+	}
+	s.trackReplies(cmd, request.Event, response)
+
+	defer func() {
+		// The user is told the command failed, without the details of the panic
+		recovered := recover()
+		if recovered != nil {
+			recorder.ReportError(errors.New(commandPanicked))
+			recorder.err = fmt.Errorf(panicFormat, recovered)
+			recorder.failed = true
+		}
+
+		err = recorder.err
+		s.stats.record(cmd.usage, err != nil)
+
+		if err != nil {
+			s.publish(&BusEvent{Name: CommandFailedEvent, Event: request.Event, Command: cmd.usage, Error: err, RequestID: recorder.requestID})
+			s.handleHandlerError(request, err)
+		}
+
+		if recorder.failed {
+			s.recordDeadLetter(cmd, request, err)
+		}
+		s.publish(&BusEvent{Name: CommandExecutedEvent, Event: request.Event, Command: cmd.usage, Error: err, RequestID: recorder.requestID})
+	}()
+
+	cmd.Execute(request, writer)
+	return nil
+}
+
+// recordDeadLetter adds the message to the dead letters, or counts another attempt if it is already there
+func (s *Slacker) recordDeadLetter(cmd *BotCommand, request *Request, err error) {
+	s.deadLettersMutex.Lock()
+	defer s.deadLettersMutex.Unlock()
+
+	event := request.Event
+	key := fmt.Sprintf(messageKeyFormat, event.Channel, event.Timestamp)
+	for _, deadLetter := range s.deadLetters {
+		if fmt.Sprintf(messageKeyFormat, deadLetter.Event.Channel, deadLetter.Event.Timestamp) == key {
+			deadLetter.Attempts++
+			deadLetter.Error = err.Error()
+			deadLetter.FailedAt = time.Now()
+			deadLetter.RequestID = request.ID()
+			return
+		}
+	}
+
+	s.deadLetterID++
+	s.deadLetters = append(s.deadLetters, &DeadLetter{
+		ID:        s.deadLetterID,
+		Command:   cmd.usage,
+		Event:     event,
+		Error:     err.Error(),
+		Attempts:  1,
+		FailedAt:  time.Now(),
+		RequestID: request.ID(),
+	})
+
+	if len(s.deadLetters) > deadLetterLimit {
+		s.deadLetters = s.deadLetters[1:]
+	}
+}
+
+func (s *Slacker) findDeadLetter(id int) *DeadLetter {
+	s.deadLettersMutex.Lock()
+	defer s.deadLettersMutex.Unlock()
+
+	for _, deadLetter := range s.deadLetters {
+		if deadLetter.ID == id {
+			return deadLetter
+		}
+	}
+	return nil
+}
+
+func (s *Slacker) removeDeadLetter(id int) {
+	s.deadLettersMutex.Lock()
+	defer s.deadLettersMutex.Unlock()
+
+	for i, deadLetter := range s.deadLetters {
+		if deadLetter.ID == id {
+			s.deadLetters = append(s.deadLetters[:i], s.deadLetters[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,349 @@
+package slacker
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultShutdownGracePeriod = 10 * time.Second
+)
+
+// ClientOption an option for client values
+type ClientOption func(*ClientDefaults)
+
+// WithEditedCommands sets whether edited messages are matched against the commands again
+func WithEditedCommands(rerun bool) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.EditedCommands = rerun
+	}
+}
+
+// WithUnmatchedEdits sets whether edited messages are matched against the commands again when the original matched none,
+// so that fixing a typo in a command runs it
+func WithUnmatchedEdits(rerun bool) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.UnmatchedEdits = rerun
+	}
+}
+
+// WithRequiredScopes sets the scopes the token must be granted for validation to pass, checked when the Authenticator is a ScopedAuthenticator
+func WithRequiredScopes(scopes ...string) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.RequiredScopes = scopes
+	}
+}
+
+// WithTokenSource sets the source consulted for the token before Web API calls, to support token rotation.
+// The Real-Time Messaging connection keeps using the token the client was created with
+func WithTokenSource(source TokenSource) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.TokenSource = source
+	}
+}
+
+// WithMessageSender replaces the Real-Time Messaging connection used to send messages, for example with a mock
+func WithMessageSender(sender MessageSender) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.MessageSender = sender
+	}
+}
+
+// WithInfoProvider replaces the Real-Time Messaging connection used to look up the bot's details
+func WithInfoProvider(info InfoProvider) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.InfoProvider = info
+	}
+}
+
+// WithMessagePoster replaces the Web API client used to post messages
+func WithMessagePoster(poster MessagePoster) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.MessagePoster = poster
+	}
+}
+
+// WithAuthenticator replaces the Web API client used to identify the bot
+func WithAuthenticator(authenticator Authenticator) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.Authenticator = authenticator
+	}
+}
+
+// WithShutdownGracePeriod sets how long Wait lets running handlers finish once the bot is closed
+func WithShutdownGracePeriod(gracePeriod time.Duration) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.ShutdownGracePeriod = gracePeriod
+	}
+}
+
+// WithSendRetries sets how many times a failed message is retried and the delay before the first retry,
+// which doubles with every attempt
+func WithSendRetries(retries int, initialDelay time.Duration) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.SendRetries = retries
+		defaults.SendInitialDelay = initialDelay
+	}
+}
+
+// WithStore sets the store persisting the bot's state. Replies that cannot be delivered while disconnected
+// are queued in it and delivered after reconnecting
+func WithStore(store Store) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.Store = store
+	}
+}
+
+// WithAdmins sets the IDs of the users allowed to use admin commands
+func WithAdmins(userIDs ...string) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.Admins = userIDs
+	}
+}
+
+// WithSessionTTL sets how long a session is kept after its last change
+func WithSessionTTL(ttl time.Duration) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.SessionTTL = ttl
+	}
+}
+
+// WithHistorySize sets how many of a user's recent messages in a channel are kept for Request.History.
+// A size of zero disables the history
+func WithHistorySize(size int) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.HistorySize = size
+	}
+}
+
+// WithSigningSecret sets the app's signing secret, used to verify that requests to the bot's HTTP handlers come from Slack.
+// It is required, the handlers rejecting every request without it
+func WithSigningSecret(secret string) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.SigningSecret = secret
+	}
+}
+
+// WithReplayWindow sets how old a signed request can be before it is rejected as replayed
+func WithReplayWindow(window time.Duration) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.ReplayWindow = window
+	}
+}
+
+// WithHTTPClient sets the client making Web API and response URL requests, for example to go through a corporate proxy
+// or use custom TLS settings and timeouts. It is set for this bot only, so several bots can use different clients.
+// The RTM and Socket Mode connections go through the proxy and use the TLS settings and timeout of the client's
+// transport when it is an *http.Transport, and the HTTP handlers make any request of their own with the client too
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.HTTPClient = client
+	}
+}
+
+// WithRTMReplies sets whether replies to messages received over the Real-Time Messaging connection are sent
+// over it, as a fallback for when the Web API cannot be used. By default they are posted with chat.postMessage,
+// which supports more options and reports errors
+func WithRTMReplies(enabled bool) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.RTMReplies = enabled
+	}
+}
+
+// WithMaxReconnectAttempts sets how many consecutive failed attempts to connect are tolerated before Listen
+// gives up and returns the last error. Zero, the default, keeps trying forever
+func WithMaxReconnectAttempts(attempts int) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.MaxReconnectAttempts = attempts
+	}
+}
+
+// WithReconnectInitialDelay sets the delay before the first attempt to connect again, doubling with every failed attempt.
+// It defaults to 100ms. The slack package waits 100ms doubling on its own, so shorter delays have no effect
+func WithReconnectInitialDelay(delay time.Duration) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.ReconnectInitialDelay = delay
+	}
+}
+
+// WithReconnectMaxDelay sets the longest delay between attempts to connect, 5 minutes by default.
+// The slack package's own delay keeps doubling, so it takes over once longer than the maximum
+func WithReconnectMaxDelay(delay time.Duration) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.ReconnectMaxDelay = delay
+	}
+}
+
+// WithReconnectJitter sets the longest random delay added to every delay between attempts to connect,
+// so bots disconnected together do not reconnect together. There is none by default
+func WithReconnectJitter(jitter time.Duration) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.ReconnectJitter = jitter
+	}
+}
+
+// WithDryRun logs outgoing messages, reactions and views with the logger instead of sending them to Slack,
+// so the bot can be tried against production traffic or replayed events without posting anything
+func WithDryRun(logger *log.Logger) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.DryRunLogger = logger
+	}
+}
+
+// WithFloodWindow sets how long a command sent again with the same text by the same user in the same channel
+// is ignored, protecting against impatient double sends running it twice. Zero, the default, disables the protection
+func WithFloodWindow(window time.Duration) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.FloodWindow = window
+	}
+}
+
+// WithBotMessages sets whether messages from other bots and workflows are matched against the commands,
+// for bot-to-bot pipelines. Commands can override it with WithCommandBotMessages. The bot's own messages are always ignored
+func WithBotMessages(allowed bool) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.BotMessages = allowed
+	}
+}
+
+// WithStrictMatching sets whether the keywords of a command must start the message, once the bot's mention is removed,
+// so that commands are not triggered by their words appearing in a sentence
+func WithStrictMatching(strict bool) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.StrictMatching = strict
+	}
+}
+
+// WithCaseSensitive sets whether the keywords of commands must be written with the same case to match.
+// Matching ignores case by default, so that capitalized messages still match
+func WithCaseSensitive(caseSensitive bool) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.CaseSensitive = caseSensitive
+	}
+}
+
+// WithAmbientChannels sets the channels, given by name or ID, where messages are matched against the commands
+// without mentioning the bot. Everywhere else, except in direct messages, the bot must be mentioned.
+// Messages not mentioning the bot in ambient channels only run the commands they match, without usage errors,
+// fallbacks or the default command answering the rest of the conversation
+func WithAmbientChannels(channels ...string) ClientOption {
+	return func(defaults *ClientDefaults) {
+		for _, channel := range channels {
+			defaults.AmbientChannels = append(defaults.AmbientChannels, strings.TrimPrefix(channel, channelPrefix))
+		}
+	}
+}
+
+// WithExternalUserRefusal sets the message replied to external users of Slack Connect shared channels
+// instead of executing the commands refused to them
+func WithExternalUserRefusal(message string) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.ExternalUserRefusal = message
+	}
+}
+
+// WithRequestIDInErrors sets whether the request ID is appended to the errors reported by commands,
+// so users can quote it when reporting issues
+func WithRequestIDInErrors(enabled bool) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.RequestIDInErrors = enabled
+	}
+}
+
+// WithHelpTriggers sets the words triggering the help, instead of help, such as when help is one of the bot's commands.
+// Include help among them to extend it instead
+func WithHelpTriggers(words ...string) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.HelpTriggers = words
+	}
+}
+
+// WithoutHelp leaves out the help command entirely, for bots using the help triggers for their own commands
+// or providing help elsewhere. The handler set with Help is then never called
+func WithoutHelp() ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.DisableHelp = true
+	}
+}
+
+// WithHelpDelivery sets where the help is replied, so that long help does not disrupt busy channels.
+// It applies to the handler set with Help too, except for InteractiveHelp, which posts to the channel
+func WithHelpDelivery(delivery HelpDelivery) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.HelpDelivery = delivery
+	}
+}
+
+// WithHelpThreadThreshold sets the number of commands above which the help posts a short index,
+// with the help of the commands in pages threaded under it. Zero, the default, always lists the commands in a single message,
+// as do responses that cannot report the timestamp of the index, such as ephemeral ones and response URLs
+func WithHelpThreadThreshold(commands int) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.HelpThreadThreshold = commands
+	}
+}
+
+// ClientDefaults configuration
+type ClientDefaults struct {
+	EditedCommands        bool
+	UnmatchedEdits        bool
+	RequiredScopes        []string
+	TokenSource           TokenSource
+	MessageSender         MessageSender
+	InfoProvider          InfoProvider
+	MessagePoster         MessagePoster
+	Authenticator         Authenticator
+	ShutdownGracePeriod   time.Duration
+	SendRetries           int
+	SendInitialDelay      time.Duration
+	Store                 Store
+	Admins                []string
+	SessionTTL            time.Duration
+	HistorySize           int
+	SigningSecret         string
+	ReplayWindow          time.Duration
+	HTTPClient            *http.Client
+	RTMReplies            bool
+	MaxReconnectAttempts  int
+	ReconnectInitialDelay time.Duration
+	ReconnectMaxDelay     time.Duration
+	ReconnectJitter       time.Duration
+	DryRunLogger          *log.Logger
+	FloodWindow           time.Duration
+	BotMessages           bool
+	StrictMatching        bool
+	CaseSensitive         bool
+	AmbientChannels       []string
+	ExternalUserRefusal   string
+	RequestIDInErrors     bool
+	HelpTriggers          []string
+	DisableHelp           bool
+	HelpDelivery          HelpDelivery
+	HelpThreadThreshold   int
+}
+
+func newClientDefaults(token string, options ...ClientOption) *ClientDefaults {
+	config := &ClientDefaults{
+		EditedCommands:        false,
+		TokenSource:           StaticTokenSource(token),
+		ShutdownGracePeriod:   defaultShutdownGracePeriod,
+		SendRetries:           defaultSendRetries,
+		SendInitialDelay:      defaultSendInitialDelay,
+		SessionTTL:            defaultSessionTTL,
+		HistorySize:           defaultHistorySize,
+		ReplayWindow:          defaultReplayWindow,
+		ReconnectInitialDelay: defaultReconnectInitialDelay,
+		ReconnectMaxDelay:     defaultReconnectMaxDelay,
+		HTTPClient:            http.DefaultClient,
+		ExternalUserRefusal:   defaultExternalUserRefusal,
+		HelpTriggers:          []string{helpCommand},
+		HelpThreadThreshold:   defaultHelpThreadThreshold,
+	}
+
+	for _, option := range options {
+		option(config)
+	}
+	return config
+}
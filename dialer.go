@@ -0,0 +1,23 @@
+package slacker
+
+import (
+	"net/http"
+
+	"github.com/gorilla/websocket"
+)
+
+// newWebsocketDialer creates the dialer of the RTM and Socket Mode connections, going through the proxy
+// and using the TLS settings and timeout of the HTTP client when it has them
+func newWebsocketDialer(client *http.Client) *websocket.Dialer {
+	dialer := *websocket.DefaultDialer
+	if client.Timeout > 0 {
+		dialer.HandshakeTimeout = client.Timeout
+	}
+
+	transport, ok := client.Transport.(*http.Transport)
+	if ok {
+		dialer.Proxy = transport.Proxy
+		dialer.TLSClientConfig = transport.TLSClientConfig
+	}
+	return &dialer
+}
@@ -0,0 +1,97 @@
+package slacker
+
+import (
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// DirectMessageOption an option for direct message values
+type DirectMessageOption func(*DirectMessageDefaults)
+
+// WithDeferDuringDND sets whether a message to a user in Do Not Disturb is held until their DND window ends
+func WithDeferDuringDND(deferred bool) DirectMessageOption {
+	return func(defaults *DirectMessageDefaults) {
+		defaults.DeferDuringDND = deferred
+	}
+}
+
+// WithDirectMessageParams sets the parameters the direct message is posted with
+func WithDirectMessageParams(params slack.PostMessageParameters) DirectMessageOption {
+	return func(defaults *DirectMessageDefaults) {
+		defaults.Params = params
+	}
+}
+
+// DirectMessageDefaults configuration
+type DirectMessageDefaults struct {
+	DeferDuringDND bool
+	Params         slack.PostMessageParameters
+}
+
+func newDirectMessageDefaults(options ...DirectMessageOption) *DirectMessageDefaults {
+	config := &DirectMessageDefaults{
+		Params: slack.NewPostMessageParameters(),
+	}
+
+	for _, option := range options {
+		option(config)
+	}
+	return config
+}
+
+// DoNotDisturbUntil returns when the user's current Do Not Disturb window or snooze ends,
+// or the zero time if they are not in Do Not Disturb
+func (s *Slacker) DoNotDisturbUntil(userID string) (time.Time, error) {
+	status, err := s.APIClient().GetDNDInfo(&userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	now := time.Now().Unix()
+	until := int64(0)
+	if status.SnoozeEnabled && int64(status.SnoozeEndTime) > now {
+		until = int64(status.SnoozeEndTime)
+	}
+	if status.Enabled && int64(status.NextStartTimestamp) <= now && int64(status.NextEndTimestamp) > now && int64(status.NextEndTimestamp) > until {
+		until = int64(status.NextEndTimestamp)
+	}
+
+	if until == 0 {
+		return time.Time{}, nil
+	}
+	return time.Unix(until, 0), nil
+}
+
+// DirectMessage sends the text to the user in a direct message, returning when it is delivered.
+// With WithDeferDuringDND, a user in Do Not Disturb gets the message once their DND window ends instead.
+// Deferred messages are kept in memory and dropped if the bot is closed before they are delivered
+func (s *Slacker) DirectMessage(userID string, text string, options ...DirectMessageOption) (time.Time, error) {
+	defaults := newDirectMessageDefaults(options...)
+	if defaults.DeferDuringDND {
+		until, err := s.DoNotDisturbUntil(userID)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if !until.IsZero() {
+			s.runAt(until, func() {
+				err := s.sendDirectMessage(userID, text, defaults.Params)
+				if err != nil {
+					s.reportError(err)
+				}
+			})
+			return until, nil
+		}
+	}
+	return time.Now(), s.sendDirectMessage(userID, text, defaults.Params)
+}
+
+// sendDirectMessage opens the direct message channel with the user and posts the text to it
+func (s *Slacker) sendDirectMessage(userID string, text string, params slack.PostMessageParameters) error {
+	channel, err := s.directChannel(userID)
+	if err != nil {
+		return err
+	}
+	return s.postMessage(channel, text, slack.MsgOptionPostMessageParameters(params))
+}
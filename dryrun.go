@@ -0,0 +1,77 @@
+package slacker
+
+import (
+	"log"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	dryRunMessageFormat   = "dry run: message to %s: %s"
+	dryRunEphemeralFormat = "dry run: ephemeral message to %s in %s"
+	dryRunAPIFormat       = "dry run: %s %s"
+	dryRunReactionFormat  = "dry run: reaction %s to %s in %s"
+	dryRunUploadFormat    = "dry run: file %s to %s"
+)
+
+// dryRunMethods are the Web API methods with visible effects, which are logged instead of called in dry-run mode
+var dryRunMethods = map[string]bool{
+	chatPostMessageMethod:        true,
+	chatUpdateMethod:             true,
+	chatDeleteMethod:             true,
+	chatUnfurlMethod:             true,
+	viewsPublishMethod:           true,
+	viewsOpenMethod:              true,
+	workflowsUpdateStepMethod:    true,
+	workflowsStepCompletedMethod: true,
+	workflowsStepFailedMethod:    true,
+}
+
+// dryRunSender logs the messages it is given instead of sending them
+type dryRunSender struct {
+	MessageSender
+	logger *log.Logger
+}
+
+// SendMessage logs the message instead of sending it
+func (d *dryRunSender) SendMessage(message *slack.OutgoingMessage) {
+	if message.Type != messageEventType {
+		return
+	}
+	d.logger.Printf(dryRunMessageFormat, message.Channel, message.Text)
+}
+
+// dryRunPoster logs the messages it is given instead of posting them
+type dryRunPoster struct {
+	logger *log.Logger
+}
+
+// PostMessage logs the message instead of posting it
+func (d *dryRunPoster) PostMessage(channel string, options ...slack.MsgOption) (string, string, error) {
+	_, values, err := slack.UnsafeApplyMsgOptions(empty, channel, slack.APIURL, options...)
+	if err != nil {
+		return empty, empty, err
+	}
+	d.logger.Printf(dryRunMessageFormat, channel, values.Get(textField))
+	return channel, empty, nil
+}
+
+// PostEphemeral logs the message instead of posting it
+func (d *dryRunPoster) PostEphemeral(channel string, userID string, options ...slack.MsgOption) (string, error) {
+	d.logger.Printf(dryRunEphemeralFormat, userID, channel)
+	return empty, nil
+}
+
+// isDryRun determines whether outgoing messages are logged instead of sent
+func (s *Slacker) isDryRun() bool {
+	return s.defaults.DryRunLogger != nil
+}
+
+// addReaction adds the reaction to the message, or logs it in dry-run mode
+func (s *Slacker) addReaction(name string, channel string, timestamp string) error {
+	if s.isDryRun() {
+		s.defaults.DryRunLogger.Printf(dryRunReactionFormat, name, timestamp, channel)
+		return nil
+	}
+	return s.APIClient().AddReaction(name, slack.NewRefToMessage(channel, timestamp))
+}
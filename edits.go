@@ -0,0 +1,28 @@
+package slacker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	unmatchedMessageTTL = time.Hour
+)
+
+// rememberUnmatched records a message addressed to the bot that matched no command, so that editing it retries the commands
+func (s *Slacker) rememberUnmatched(event *slack.MessageEvent) {
+	if !s.defaults.UnmatchedEdits {
+		return
+	}
+	s.unmatchedMessages.Seen(fmt.Sprintf(messageKeyFormat, event.Channel, event.Timestamp))
+}
+
+// isRetryableEdit determines whether the edited message should be matched against the commands again
+func (s *Slacker) isRetryableEdit(edited *slack.MessageEvent) bool {
+	if s.defaults.EditedCommands {
+		return true
+	}
+	return s.defaults.UnmatchedEdits && s.unmatchedMessages.Forget(fmt.Sprintf(messageKeyFormat, edited.Channel, edited.Timestamp))
+}
@@ -0,0 +1,103 @@
+package slacker
+
+import (
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	teamFailureTTL = 5 * time.Minute
+)
+
+// messageTeams are the workspaces involved in a message event, which differ from the event's workspace
+// in Enterprise Grid organizations and shared channels
+type messageTeams struct {
+	UserTeam string `json:"user_team"`
+}
+
+// WithEnterprises restricts the command to the workspaces of the Enterprise Grid organizations with the IDs
+func WithEnterprises(enterpriseIDs ...string) CommandOption {
+	return func(command *BotCommand) {
+		command.enterprises = append(command.enterprises, enterpriseIDs...)
+	}
+}
+
+// isInEnterprise determines whether the enterprise ID is one the command is restricted to
+func (c *BotCommand) isInEnterprise(enterpriseID string) bool {
+	for _, allowed := range c.enterprises {
+		if allowed == enterpriseID {
+			return true
+		}
+	}
+	return false
+}
+
+// rememberEnterprise records the organization the workspace belongs to, as given by the Events API
+func (s *Slacker) rememberEnterprise(teamID string, enterpriseID string) {
+	if len(teamID) == 0 {
+		return
+	}
+
+	s.teamsMutex.Lock()
+	defer s.teamsMutex.Unlock()
+	s.enterprises[teamID] = enterpriseID
+}
+
+// EnterpriseID returns the ID of the Enterprise Grid organization the workspace belongs to, or empty for a standalone workspace.
+// It is known from the events received, or else looked up with TeamInfo. Workspaces failing to be looked up,
+// such as those of other organizations, are considered standalone for a few minutes before being looked up again
+func (s *Slacker) EnterpriseID(teamID string) string {
+	s.teamsMutex.Lock()
+	enterpriseID, ok := s.enterprises[teamID]
+	s.teamsMutex.Unlock()
+	if ok || s.failedTeams.Has(teamID) {
+		return enterpriseID
+	}
+
+	team, err := s.TeamInfo(teamID)
+	if err != nil {
+		if !s.failedTeams.Seen(teamID) {
+			s.reportError(err)
+		}
+		return empty
+	}
+
+	s.rememberEnterprise(teamID, team.EnterpriseID)
+	return team.EnterpriseID
+}
+
+// EnterpriseID returns the ID of the Enterprise Grid organization the message was sent in, or empty for a standalone workspace
+func (r *Request) EnterpriseID() string {
+	if len(r.enterpriseID) > 0 || r.slacker == nil {
+		return r.enterpriseID
+	}
+	return r.slacker.EnterpriseID(r.Event.Team)
+}
+
+// UserTeamID returns the ID of the workspace of the user who sent the message. It differs from TeamID
+// for users of other workspaces of the organization, or of other organizations in shared channels.
+// When the event does not tell, it is resolved from the user's profile
+func (r *Request) UserTeamID() string {
+	if r.slacker == nil {
+		if len(r.userTeamID) > 0 {
+			return r.userTeamID
+		}
+		return r.Event.Team
+	}
+	return r.slacker.userTeamID(r.Event, r.userTeamID)
+}
+
+// userTeamID returns the ID of the workspace of the user who sent the message, if not already known
+// from the event, resolved from the user's profile or else assumed to be the event's workspace
+func (s *Slacker) userTeamID(event *slack.MessageEvent, known string) string {
+	if len(known) > 0 {
+		return known
+	}
+
+	user, err := s.UserInfo(event.User)
+	if err != nil || len(user.TeamID) == 0 {
+		return event.Team
+	}
+	return user.TeamID
+}
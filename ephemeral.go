@@ -0,0 +1,101 @@
+package slacker
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/shomali11/slacker/format"
+	"github.com/slack-go/slack"
+)
+
+const (
+	cannotShareImage = "the image cannot be shared, as files cannot be shared ephemerally"
+)
+
+// HelpDelivery is where the help is replied
+type HelpDelivery int
+
+const (
+	// HelpInChannel replies the help to the channel it was asked in
+	HelpInChannel HelpDelivery = iota
+
+	// HelpEphemeral replies the help to the channel it was asked in, visible only to the user who asked
+	HelpEphemeral
+
+	// HelpDirectMessage replies the help to the user who asked in a direct message
+	HelpDirectMessage
+)
+
+// newEphemeralResponse creates a response replying with messages only the user can see
+func newEphemeralResponse(channel string, userID string, slacker *Slacker) *ephemeralResponse {
+	return &ephemeralResponse{channel: channel, userID: userID, slacker: slacker}
+}
+
+// ephemeralResponse posts replies visible only to a user of the channel, which are not kept once they leave it
+type ephemeralResponse struct {
+	channel string
+	userID  string
+	slacker *Slacker
+}
+
+// Reply send a message back to the user in the channel where we received the event from
+func (r *ephemeralResponse) Reply(text string, options ...ReplyOption) {
+	err := r.post(text, options...)
+	if err != nil {
+		r.slacker.reportError(err)
+	}
+}
+
+// ReplyTemporary fails with ErrCannotDelete, as ephemeral messages cannot be deleted
+func (r *ephemeralResponse) ReplyTemporary(text string, ttl time.Duration, options ...ReplyOption) error {
+	return ErrCannotDelete
+}
+
+// ReplyTable send a table back to the user in the channel where we received the event from.
+// Files cannot be shared ephemerally, so the table is always replied in a code block
+func (r *ephemeralResponse) ReplyTable(headers []string, rows [][]string, options ...ReplyOption) error {
+	return r.post(format.CodeBlock(renderTable(headers, rows)), options...)
+}
+
+// ReplyImage fails with ErrCannotShareImage, as files cannot be shared ephemerally
+func (r *ephemeralResponse) ReplyImage(name string, png io.Reader, caption string, options ...ReplyOption) error {
+	return ErrCannotShareImage
+}
+
+// Permalink returns a link to the message with the timestamp in the channel where we received the event from
+func (r *ephemeralResponse) Permalink(timestamp string) (string, error) {
+	return r.slacker.Permalink(r.channel, timestamp)
+}
+
+// ReportError sends back a formatted error message to the user in the channel where we received the event from
+func (r *ephemeralResponse) ReportError(err error) {
+	r.Reply(fmt.Sprintf(errorFormat, err.Error()))
+}
+
+// Typing is not visible to a single user, so it does nothing
+func (r *ephemeralResponse) Typing() {}
+
+func (r *ephemeralResponse) post(text string, options ...ReplyOption) error {
+	msgOptions := append([]slack.MsgOption{slack.MsgOptionText(text, false)}, NewReplyDefaults(options...).msgOptions()...)
+	_, err := r.slacker.poster.PostEphemeral(r.channel, r.userID, msgOptions...)
+	return err
+}
+
+// helpResponse returns the response the help is replied through, depending on its delivery,
+// created with the response constructor like the other responses
+func (s *Slacker) helpResponse(request *Request, response ResponseWriter) ResponseWriter {
+	switch s.defaults.HelpDelivery {
+	case HelpEphemeral:
+		return s.newResponse(request.Event, newEphemeralResponse(request.Event.Channel, request.Event.User, s))
+
+	case HelpDirectMessage:
+		channel, err := s.directChannel(request.Event.User)
+		if err != nil {
+			s.reportRequestError(request.Context, err)
+			return response
+		}
+		return s.newResponse(request.Event, newAPIResponse(channel, s))
+	}
+	return response
+}
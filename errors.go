@@ -0,0 +1,66 @@
+package slacker
+
+import (
+	"errors"
+)
+
+const (
+	invalidAuth        = "invalid auth"
+	disconnected       = "disconnected"
+	missingScopesError = "missing scopes"
+	wrappedFormat      = "%w: %s"
+)
+
+var (
+	// ErrInvalidAuth is returned when the token is missing, invalid, revoked or expired, including by Listen
+	// when the connection is refused it
+	ErrInvalidAuth = errors.New(invalidAuth)
+
+	// ErrMissingScopes is returned when the token lacks any of the required scopes
+	ErrMissingScopes = errors.New(missingScopesError)
+
+	// ErrDisconnected is returned by Listen when the connection could not be established again within the allowed attempts
+	ErrDisconnected = errors.New(disconnected)
+
+	// ErrNoBotClient is returned by the Request methods calling the Web API, and the replies requiring it, when the request was not created by a bot
+	ErrNoBotClient = errors.New(noBotClient)
+
+	// ErrNotInThread is returned when looking up the thread of a message sent at the top level
+	ErrNotInThread = errors.New(notInThread)
+
+	// ErrParentNotFound is returned when the message starting a thread could not be found
+	ErrParentNotFound = errors.New(parentNotFound)
+
+	// ErrUserNotFound is returned when no user has the name looked up
+	ErrUserNotFound = errors.New(userNotFound)
+
+	// ErrDeadLetterNotFound is returned when replaying a dead letter that does not exist
+	ErrDeadLetterNotFound = errors.New(deadLetterNotFound)
+
+	// ErrCommandNotAllowed is returned when re-running a command the user who reacted is not allowed to use
+	ErrCommandNotAllowed = errors.New(commandNotAllowed)
+
+	// ErrFormInProgress is returned when starting a form in direct messages with a user filling in another one
+	ErrFormInProgress = errors.New(formInProgress)
+
+	// ErrCannotDelete is returned when replying with a temporary message the response could not delete
+	ErrCannotDelete = errors.New(cannotDelete)
+
+	// ErrCannotShareImage is returned when replying with an image the response could not share
+	ErrCannotShareImage = errors.New(cannotShareImage)
+
+	// ErrShutdownTimeout is returned when handlers did not finish within the shutdown grace period
+	ErrShutdownTimeout = errors.New(shutdownTimeout)
+
+	// ErrClosed is returned for the work left undone because the bot was closed
+	ErrClosed = errors.New(botClosed)
+
+	// ErrInvalidSignature is reported when a request's signature does not match the signing secret
+	ErrInvalidSignature = errors.New(invalidSignature)
+
+	// ErrNoSigningSecret is reported when a request is rejected because no signing secret was set to verify it
+	ErrNoSigningSecret = errors.New(noSigningSecret)
+
+	// ErrStaleRequest is reported when a request's timestamp is outside the replay window
+	ErrStaleRequest = errors.New(staleRequest)
+)
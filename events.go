@@ -0,0 +1,193 @@
+package slacker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	urlVerificationType   = "url_verification"
+	eventCallbackType     = "event_callback"
+	messageEventType      = "message"
+	appMentionEventType   = "app_mention"
+	retryNumHeader        = "X-Slack-Retry-Num"
+	textContentType       = "text/plain"
+	contentTypeHeader     = "Content-Type"
+	eventDeduplicationTTL = 10 * time.Minute
+)
+
+// eventsPayload is the envelope Slack's Events API posts to the request URL
+type eventsPayload struct {
+	Type         string          `json:"type"`
+	Challenge    string          `json:"challenge"`
+	TeamID       string          `json:"team_id"`
+	EnterpriseID string          `json:"enterprise_id"`
+	EventID      string          `json:"event_id"`
+	Event        json.RawMessage `json:"event"`
+}
+
+// messageFiles are the files attached to a message event
+type messageFiles struct {
+	Files []*slack.File `json:"files"`
+}
+
+// callbackEvent is used to peek at the type of an Events API event
+type callbackEvent struct {
+	Type string `json:"type"`
+}
+
+// EventsHandler returns an http.Handler serving Slack's Events API request URL.
+// Events are acknowledged immediately, well within Slack's three seconds, and handled asynchronously.
+// Redelivered events are dropped.
+// Handler contexts are derived from the given context, carrying its deadline, cancellation and values.
+// Once the bot is closed, the contexts of running handlers are canceled and new events are refused
+func (s *Slacker) EventsHandler(ctx context.Context) http.Handler {
+	s.start()
+
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		<-s.done
+		cancel()
+	}()
+
+	return s.VerifySignature(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if s.isClosed() {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		s.serveEvents(ctx, writer, request)
+	}))
+}
+
+func (s *Slacker) serveEvents(ctx context.Context, writer http.ResponseWriter, request *http.Request) {
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	payload := &eventsPayload{}
+	err = json.Unmarshal(body, payload)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	switch payload.Type {
+	case urlVerificationType:
+		writer.Header().Set(contentTypeHeader, textContentType)
+		writer.Write([]byte(payload.Challenge))
+
+	case eventCallbackType:
+		writer.WriteHeader(http.StatusOK)
+
+		// Slack retries events that were not acknowledged in time, setting the retry header.
+		// Events are remembered by ID so a redelivery is only acknowledged
+		if s.eventCache.Seen(payload.EventID) && len(request.Header.Get(retryNumHeader)) > 0 {
+			return
+		}
+		s.goHandle(func() {
+			s.handleCallbackEvent(ctx, payload)
+		})
+
+	default:
+		writer.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func (s *Slacker) handleCallbackEvent(ctx context.Context, payload *eventsPayload) {
+	data := payload.Event
+	s.rememberEnterprise(payload.TeamID, payload.EnterpriseID)
+
+	_, err := s.apiToken()
+	if err != nil {
+		s.reportError(err)
+		return
+	}
+
+	event := &callbackEvent{}
+	err = json.Unmarshal(data, event)
+	if err != nil {
+		s.reportError(err)
+		return
+	}
+
+	switch event.Type {
+	case messageEventType, appMentionEventType:
+		message := &slack.MessageEvent{}
+		err := json.Unmarshal(data, message)
+		if err != nil {
+			s.reportError(err)
+			return
+		}
+
+		// Messages are routed to the workspace they were delivered for, which for users of shared channels
+		// differs from the workspace of the sender given by the message
+		teams := &messageTeams{}
+		err = json.Unmarshal(data, teams)
+		if err != nil {
+			s.reportError(err)
+		}
+
+		if len(teams.UserTeam) == 0 && message.Team != payload.TeamID {
+			teams.UserTeam = message.Team
+		}
+
+		if len(payload.TeamID) > 0 {
+			message.Team = payload.TeamID
+		}
+
+		// Mentions are delivered both as messages and as app mentions when subscribed to both
+		if s.eventCache.Seen(fmt.Sprintf(messageKeyFormat, message.Channel, message.Timestamp)) {
+			return
+		}
+
+		// The files attached to a message are not part of the slack client's messages
+		files := &messageFiles{}
+		err = json.Unmarshal(data, files)
+		if err != nil {
+			s.reportError(err)
+		}
+
+		ctx = withTransport(ctx, &transportDetails{
+			transport:    EventsTransport,
+			files:        files.Files,
+			enterpriseID: payload.EnterpriseID,
+			userTeamID:   teams.UserTeam,
+		})
+		s.handleMessageEvent(ctx, message, s.newResponse(message, newAPIResponse(message.Channel, s)))
+
+	case appHomeOpenedEventType:
+		s.handleAppHomeOpened(data)
+
+	case workflowStepExecuteEventType:
+		s.handleWorkflowStepExecute(data)
+
+	case memberJoinedChannelEventType:
+		s.handleMemberJoinedChannel(data)
+
+	case linkSharedEventType:
+		s.handleLinkShared(data)
+
+	case reactionAddedEventType:
+		s.handleReactionAddedData(data)
+
+	case reactionRemovedEventType:
+		s.handleReactionRemovedData(data)
+
+	case fileSharedEventType:
+		s.handleFileSharedData(data)
+
+	default:
+		if s.defaultEventHandler == nil {
+			return
+		}
+		s.defaultEventHandler(data)
+	}
+}
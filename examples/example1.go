@@ -1,19 +1,23 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"github.com/shomali11/slacker"
 )
 
 func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	bot := slacker.NewClient("<YOUR SLACK BOT TOKEN>")
 
 	bot.Command("ping", "Ping!", func(request *slacker.Request, response slacker.ResponseWriter) {
 		response.Reply("pong")
 	})
 
-	err := bot.Listen()
+	err := bot.Listen(ctx)
 	if err != nil {
 		log.Fatal(err)
 	}
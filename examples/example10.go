@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/shomali11/slacker"
+)
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bot := slacker.NewClient("<YOUR SLACK BOT TOKEN>", slacker.WithSigningSecret("<YOUR SLACK SIGNING SECRET>"))
+
+	bot.Command("ping", "Ping!", func(request *slacker.Request, response slacker.ResponseWriter) {
+		response.Reply("pong")
+	})
+
+	http.Handle("/slack/events", bot.EventsHandler(ctx))
+
+	err := http.ListenAndServe(":3000", nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/shomali11/slacker"
+)
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bot := slacker.NewClient("<YOUR SLACK BOT TOKEN>", slacker.WithEditedCommands(true))
+
+	bot.Command("ping", "Ping!", func(request *slacker.Request, response slacker.ResponseWriter) {
+		response.Reply("pong")
+	})
+
+	bot.OnMessageEdited(func(request *slacker.Request, response slacker.ResponseWriter) {
+		log.Println("Edited:", request.Text())
+	})
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
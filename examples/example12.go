@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/shomali11/slacker"
+	"github.com/slack-go/slack"
+)
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bot := slacker.NewClient("<YOUR SLACK BOT TOKEN>", slacker.WithSigningSecret("<YOUR SLACK SIGNING SECRET>"))
+
+	bot.OnAppHomeOpened(func(event *slacker.AppHomeOpenedEvent) {
+		welcome := slack.NewTextBlockObject(slack.MarkdownType, "*Welcome home!*", false, false)
+		hint := slack.NewTextBlockObject(slack.MarkdownType, "Mention me with `help` to see what I can do", false, false)
+
+		view := slacker.NewHomeView(
+			slack.NewSectionBlock(welcome, nil, nil),
+			slack.NewDividerBlock(),
+			slack.NewContextBlock("", hint),
+		)
+
+		err := bot.PublishHomeView(event.User, view)
+		if err != nil {
+			log.Println(err)
+		}
+	})
+
+	http.Handle("/slack/events", bot.EventsHandler(ctx))
+
+	err := http.ListenAndServe(":3000", nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/shomali11/slacker"
+	"github.com/slack-go/slack"
+)
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bot := slacker.NewClient("<YOUR SLACK BOT TOKEN>", slacker.WithSigningSecret("<YOUR SLACK SIGNING SECRET>"))
+
+	bot.WorkflowStep("shout", &slacker.WorkflowStep{
+		Edit: func(edit *slacker.WorkflowStepEdit) {
+			label := slack.NewTextBlockObject(slack.PlainTextType, "Text to shout", false, false)
+			element := slack.NewPlainTextInputBlockElement(nil, "text")
+			err := edit.Configure(slack.NewInputBlock("text", label, nil, element))
+			if err != nil {
+				log.Println(err)
+			}
+		},
+		Save: func(save *slacker.WorkflowStepSave) {
+			inputs := map[string]*slacker.WorkflowStepInput{
+				"text": slacker.NewWorkflowStepInput(save.Values["text"]["text"].Value),
+			}
+
+			err := save.Update(inputs, slacker.NewWorkflowStepOutput("shouted", "Shouted text"))
+			if err != nil {
+				log.Println(err)
+			}
+		},
+		Execute: func(execution *slacker.WorkflowStepExecution) {
+			text, ok := execution.Inputs["text"].Value.(string)
+			if !ok {
+				execution.Fail("Missing text")
+				return
+			}
+
+			err := execution.Complete(map[string]interface{}{"shouted": strings.ToUpper(text)})
+			if err != nil {
+				log.Println(err)
+			}
+		},
+	})
+
+	http.Handle("/slack/events", bot.EventsHandler(ctx))
+	http.Handle("/slack/interactions", bot.InteractionsHandler())
+
+	err := http.ListenAndServe(":3000", nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
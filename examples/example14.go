@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/shomali11/slacker"
+)
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bot := slacker.NewClient("<YOUR SLACK BOT TOKEN>", slacker.WithShutdownGracePeriod(30*time.Second))
+
+	bot.Command("deploy", "Deploy!", func(request *slacker.Request, response slacker.ResponseWriter) {
+		select {
+		case <-request.Context.Done():
+			response.Reply("Deployment interrupted, shutting down")
+		case <-time.After(time.Minute):
+			response.Reply("Deployment done!")
+		}
+	})
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-signals
+		cancel()
+	}()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = bot.Wait()
+	if err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/shomali11/slacker"
+)
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bot := slacker.NewClient("<YOUR SLACK BOT TOKEN>")
+
+	bot.Command("deploy <env>", "Deploy to an environment, defaults to the last one used", func(request *slacker.Request, response slacker.ResponseWriter) {
+		session, err := request.Session()
+		if err != nil {
+			response.ReportError(err)
+			return
+		}
+
+		lastEnv, _ := session.Get("env")
+		env := request.StringParam("env", lastEnv)
+		if len(env) == 0 {
+			response.Reply("Which environment?")
+			return
+		}
+
+		err = session.Set("env", env)
+		if err != nil {
+			response.ReportError(err)
+			return
+		}
+		response.Reply("Deploying to " + env)
+	})
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
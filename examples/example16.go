@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/shomali11/slacker"
+)
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bot := slacker.NewClient("<YOUR SLACK BOT TOKEN>", slacker.WithSigningSecret("<YOUR SLACK SIGNING SECRET>"))
+
+	bot.Command("deploy <env>", "Deploy to an environment", func(request *slacker.Request, response slacker.ResponseWriter) {
+		env := request.Param("env")
+		if request.Transport == slacker.SlashCommandTransport {
+			log.Printf("%s used /deploy", request.SlashCommand.UserName)
+		}
+		response.Reply("Deploying to " + env)
+	})
+
+	http.Handle("/slack/events", bot.EventsHandler(ctx))
+	http.Handle("/slack/commands", bot.SlashCommandsHandler(ctx))
+
+	err := http.ListenAndServe(":3000", nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
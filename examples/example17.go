@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/shomali11/slacker"
+)
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bot := slacker.NewClient("<YOUR SLACK BOT TOKEN>")
+
+	jira := bot.Namespace("jira", "Manage Jira issues")
+
+	jira.Command("create <project:OPS|WEB> <title...>", "Create an issue", func(request *slacker.Request, response slacker.ResponseWriter) {
+		response.Reply("Creating " + request.Param("title") + " in " + request.Param("project"))
+	}, slacker.WithParamDescription("project", "the project the issue belongs to"))
+
+	jira.Command("show <key> [field]", "Show an issue", func(request *slacker.Request, response slacker.ResponseWriter) {
+		response.Reply("Showing " + request.StringParam("field", "summary") + " of " + request.Param("key"))
+	})
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
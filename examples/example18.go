@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/shomali11/slacker"
+)
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bot := slacker.NewClient("<YOUR SLACK BOT TOKEN>", slacker.WithSigningSecret("<YOUR SLACK SIGNING SECRET>"))
+
+	form := slacker.NewForm("Request time off").
+		Date("start", "First day").
+		Number("days", "Number of days").
+		Choice("type", "Type", "vacation", "sick", "other").
+		Text("note", "Note").
+		Optional("note")
+
+	bot.Command("timeoff", "Request time off", func(request *slacker.Request, response slacker.ResponseWriter) {
+		err := bot.StartForm(request, form, func(answers *slacker.FormAnswers, response slacker.ResponseWriter) {
+			start := answers.Date("start").Format("January 2")
+			response.Reply(fmt.Sprintf("Requested %d days of %s from %s", answers.IntegerParam("days", 0), answers.Param("type"), start))
+		})
+		if err != nil {
+			response.ReportError(err)
+		}
+	})
+
+	http.Handle("/slack/events", bot.EventsHandler(ctx))
+	http.Handle("/slack/commands", bot.SlashCommandsHandler(ctx))
+	http.Handle("/slack/interactions", bot.InteractionsHandler())
+
+	err := http.ListenAndServe(":3000", nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
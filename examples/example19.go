@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/shomali11/slacker"
+)
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bot := slacker.NewClient("<YOUR SLACK BOT TOKEN>")
+
+	bot.ResultCommand("status <service>", "Check a service", func(request *slacker.Request) *slacker.Result {
+		service := request.Param("service")
+		if service != "api" {
+			return slacker.NewResult(slacker.ResultFailure, "Unknown service").
+				WithDetails("Only `api` is monitored")
+		}
+
+		return slacker.NewResult(slacker.ResultSuccess, "api is healthy").
+			Field("Latency", "42ms", true).
+			Field("Uptime", "99.98%", true)
+	})
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"fmt"
@@ -8,6 +9,9 @@ import (
 )
 
 func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	bot := slacker.NewClient("<YOUR SLACK BOT TOKEN>")
 
 	bot.Init(func() {
@@ -30,7 +34,7 @@ func main() {
 		response.Reply("Your own help function...")
 	})
 
-	err := bot.Listen()
+	err := bot.Listen(ctx)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/shomali11/slacker"
+	"github.com/slack-go/slack"
+)
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bot := slacker.NewClient("<YOUR SLACK BOT TOKEN>")
+
+	bot.Command("ping", "Ping!", func(request *slacker.Request, response slacker.ResponseWriter) {
+		text := slack.NewTextBlockObject(slack.MarkdownType, "*pong*", false, false)
+		response.Reply("pong", slacker.WithBlocks(slack.NewSectionBlock(text, nil, nil)))
+	})
+
+	err := bot.ListenSocketMode(ctx, "<YOUR SLACK APP TOKEN>")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -1,12 +1,16 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"github.com/shomali11/slacker"
 )
 
 func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	bot := slacker.NewClient("<YOUR SLACK BOT TOKEN>")
 
 	bot.Command("echo <word>", "Echo a word!", func(request *slacker.Request, response slacker.ResponseWriter) {
@@ -14,7 +18,7 @@ func main() {
 		response.Reply(word)
 	})
 
-	err := bot.Listen()
+	err := bot.Listen(ctx)
 	if err != nil {
 		log.Fatal(err)
 	}
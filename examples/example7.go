@@ -1,24 +1,28 @@
 package main
 
 import (
+	"context"
 	"log"
 
-	"github.com/nlopes/slack"
 	"github.com/shomali11/slacker"
+	"github.com/slack-go/slack"
 )
 
 func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	bot := slacker.NewClient("<YOUR SLACK BOT TOKEN>")
 
 	bot.Command("upload <word>", "Upload a word!", func(request *slacker.Request, response slacker.ResponseWriter) {
 		word := request.Param("word")
-		channel := request.Event.Channel
+		channel := request.ChannelID()
 
 		bot.RTM.SendMessage(bot.RTM.NewOutgoingMessage("Uploading file ...", channel))
-		bot.Client.UploadFile(slack.FileUploadParameters{Content: word, Channels: []string{channel}})
+		request.Client.UploadFileV2(slack.UploadFileV2Parameters{Content: word, FileSize: len(word), Filename: word + ".txt", Channel: channel})
 	})
 
-	err := bot.Listen()
+	err := bot.Listen(ctx)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -9,6 +9,9 @@ import (
 )
 
 func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	bot := slacker.NewClient("<YOUR SLACK BOT TOKEN>")
 
 	bot.Command("process", "Process!", func(request *slacker.Request, response slacker.ResponseWriter) {
@@ -23,7 +26,7 @@ func main() {
 		}
 	})
 
-	err := bot.Listen()
+	err := bot.Listen(ctx)
 	if err != nil {
 		log.Fatal(err)
 	}
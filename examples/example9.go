@@ -0,0 +1,22 @@
+package main
+
+import (
+	"errors"
+	"log"
+
+	"github.com/shomali11/slacker"
+)
+
+func main() {
+	webhook := slacker.Webhook("<YOUR INCOMING WEBHOOK URL>")
+
+	err := webhook.Reply("Deployment started")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = webhook.ReportError(errors.New("Deployment failed"))
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,37 @@
+package slacker
+
+import (
+	"github.com/slack-go/slack"
+)
+
+const (
+	defaultExternalUserRefusal = "This command is not available to users outside of the workspace"
+)
+
+// WithoutExternalUsers refuses the command to users of other organizations in Slack Connect shared channels
+func WithoutExternalUsers() CommandOption {
+	return func(command *BotCommand) {
+		command.internalOnly = true
+	}
+}
+
+// isExternalUser determines whether the user who sent the message, from the workspace with the ID, is outside
+// of the workspace the message was received in and its Enterprise Grid organization.
+// The user's workspace is only known for messages received through the Events API
+func (s *Slacker) isExternalUser(event *slack.MessageEvent, userTeamID string) bool {
+	if len(userTeamID) == 0 || len(event.Team) == 0 || userTeamID == event.Team {
+		return false
+	}
+
+	enterpriseID := s.EnterpriseID(event.Team)
+	return len(enterpriseID) == 0 || enterpriseID != s.EnterpriseID(userTeamID)
+}
+
+// IsExternalUser determines whether the user who sent the message is from another organization,
+// as users of Slack Connect shared channels are
+func (r *Request) IsExternalUser() bool {
+	if r.slacker == nil {
+		return false
+	}
+	return r.slacker.isExternalUser(r.Event, r.UserTeamID())
+}
@@ -0,0 +1,154 @@
+package slacker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	fileSharedEventType      = "file_shared"
+	filesInfoMethod          = "files.info"
+	fileField                = "file"
+	fileDownloadStatusFormat = "file download responded with status %d"
+)
+
+// FileSharedEvent is received when a file is shared in a channel the bot is in
+type FileSharedEvent struct {
+	FileID         string `json:"file_id"`
+	UserID         string `json:"user_id"`
+	ChannelID      string `json:"channel_id"`
+	EventTimestamp string `json:"event_ts"`
+}
+
+// filesInfoResponse is the files.info response
+type filesInfoResponse struct {
+	File *slack.File `json:"file"`
+}
+
+// OnFileShared handle files being shared in the channels the bot is in. Over the RTM connection,
+// only the file ID is known, use FileInfo for the rest
+func (s *Slacker) OnFileShared(fileSharedHandler func(event *FileSharedEvent)) {
+	s.fileSharedHandler = fileSharedHandler
+}
+
+// FileInfo returns the metadata of the file, including the URLs to download it from
+func (s *Slacker) FileInfo(fileID string) (*slack.File, error) {
+	response := &filesInfoResponse{}
+	err := s.queryAPI(filesInfoMethod, url.Values{fileField: {fileID}}, response)
+	if err != nil {
+		return nil, err
+	}
+	return response.File, nil
+}
+
+// DownloadFile returns the content of the file, which the caller must close.
+// Private files are downloaded with the bot token, which requires the files:read scope
+func (s *Slacker) DownloadFile(file *slack.File) (io.ReadCloser, error) {
+	token, err := s.apiToken()
+	if err != nil {
+		return nil, err
+	}
+
+	location := file.URLPrivateDownload
+	if len(location) == 0 {
+		location = file.URLPrivate
+	}
+
+	request, err := http.NewRequest(http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set(authorizationHeader, bearerPrefix+token)
+
+	response, err := s.defaults.HTTPClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		return nil, fmt.Errorf(fileDownloadStatusFormat, response.StatusCode)
+	}
+	return response.Body, nil
+}
+
+// uploadFile uploads the content as a file shared in the channel, in the thread if given one,
+// along with the comment, if any. Text is uploaded as a snippet of the type, if given one
+func (s *Slacker) uploadFile(channel string, name string, snippetType string, content io.Reader, comment string, threadTimestamp string) error {
+	data, err := ioutil.ReadAll(content)
+	if err != nil {
+		return err
+	}
+
+	if s.isDryRun() {
+		s.defaults.DryRunLogger.Printf(dryRunUploadFormat, name, channel)
+		return nil
+	}
+
+	_, err = s.APIClient().UploadFileV2(slack.UploadFileV2Parameters{
+		Reader:          bytes.NewReader(data),
+		FileSize:        len(data),
+		Filename:        name,
+		Title:           name,
+		SnippetType:     snippetType,
+		InitialComment:  comment,
+		Channel:         channel,
+		ThreadTimestamp: threadTimestamp,
+	})
+	return err
+}
+
+func (s *Slacker) handleFileSharedData(data json.RawMessage) {
+	event := &FileSharedEvent{}
+	err := json.Unmarshal(data, event)
+	if err != nil {
+		s.reportError(err)
+		return
+	}
+	s.handleFileShared(event)
+}
+
+func (s *Slacker) handleFileShared(event *FileSharedEvent) {
+	if s.fileSharedHandler == nil {
+		return
+	}
+	s.fileSharedHandler(event)
+}
+
+// AttachedFile is a file attached to the message being handled
+type AttachedFile struct {
+	*slack.File
+	slacker *Slacker
+}
+
+// Open returns the content of the file, which the caller must close.
+// Downloading requires the bot token, so it fails with ErrNoBotClient when the request was not created by a bot
+func (f *AttachedFile) Open() (io.ReadCloser, error) {
+	if f.slacker == nil {
+		return nil, ErrNoBotClient
+	}
+	return f.slacker.DownloadFile(f.File)
+}
+
+// Files returns the files attached to the message, with their metadata and content
+func (r *Request) Files() []*AttachedFile {
+	files := r.files
+	if len(files) == 0 {
+		for i := range r.Event.Files {
+			files = append(files, &r.Event.Files[i])
+		}
+	}
+
+	attached := make([]*AttachedFile, 0, len(files))
+	for _, file := range files {
+		attached = append(attached, &AttachedFile{File: file, slacker: r.slacker})
+	}
+	return attached
+}
@@ -0,0 +1,145 @@
+// Package filestore provides a slacker.Store persisted to a local file, for single instance bots that want state to survive restarts
+package filestore
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/shomali11/slacker"
+)
+
+const (
+	tempFilePattern = ".slacker-store-"
+	filePermissions = 0600
+)
+
+var _ slacker.Store = (*Store)(nil)
+
+// Open creates a store persisted to the file at the path, loading its values if the file exists
+func Open(path string) (*Store, error) {
+	store := &Store{path: path, entries: make(map[string]*entry)}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return store, nil
+	}
+
+	err = json.Unmarshal(data, &store.entries)
+	if err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// Store keeps values in memory and writes them all to its file on every change.
+// The file is replaced atomically, so a crash never leaves it half written
+type Store struct {
+	mutex   sync.Mutex
+	path    string
+	entries map[string]*entry
+}
+
+// entry is a value and the time it expires, if any
+type entry struct {
+	Value  []byte    `json:"value"`
+	Expiry time.Time `json:"expiry"`
+}
+
+// Get returns the value stored under the key and whether it was found
+func (s *Store) Get(key string) ([]byte, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok || entry.isExpired(time.Now()) {
+		return nil, false, nil
+	}
+	return entry.Value, true, nil
+}
+
+// Set stores the value under the key, expiring it after the TTL unless the TTL is zero
+func (s *Store) Set(key string, value []byte, ttl time.Duration) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry := &entry{Value: value}
+	if ttl > 0 {
+		entry.Expiry = time.Now().Add(ttl)
+	}
+
+	s.entries[key] = entry
+	return s.save()
+}
+
+// Delete removes the key
+func (s *Store) Delete(key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, ok := s.entries[key]
+	if !ok {
+		return nil
+	}
+
+	delete(s.entries, key)
+	return s.save()
+}
+
+// save drops expired values and writes the rest to a temporary file that then replaces the store's file
+func (s *Store) save() error {
+	now := time.Now()
+	for key, entry := range s.entries {
+		if entry.isExpired(now) {
+			delete(s.entries, key)
+		}
+	}
+
+	data, err := json.Marshal(s.entries)
+	if err != nil {
+		return err
+	}
+
+	file, err := ioutil.TempFile(filepath.Dir(s.path), tempFilePattern)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Chmod(file.Name(), filePermissions)
+	}
+
+	if err == nil {
+		err = os.Rename(file.Name(), s.path)
+	}
+
+	if err != nil {
+		os.Remove(file.Name())
+	}
+	return err
+}
+
+func (e *entry) isExpired(now time.Time) bool {
+	return !e.Expiry.IsZero() && now.After(e.Expiry)
+}
@@ -0,0 +1,34 @@
+package slacker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	floodKeyFormat = "%s:%s:%s:%s:%s"
+)
+
+// replayKey is the context key marking messages handled again on purpose
+type replayKey struct{}
+
+// withReplay returns a context marking the message as handled again, by a replay or a re-run,
+// which the flood protection must not ignore
+func withReplay(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replayKey{}, true)
+}
+
+// isFlooding determines whether the user already sent the command with the same text in the channel within the flood window.
+// Messages handled again on purpose are never flooding
+func (s *Slacker) isFlooding(ctx context.Context, command *BotCommand, event *slack.MessageEvent) bool {
+	replayed, _ := ctx.Value(replayKey{}).(bool)
+	if s.floodCache == nil || replayed {
+		return false
+	}
+
+	text := strings.ToLower(strings.Join(strings.Fields(event.Text), space))
+	return s.floodCache.Seen(fmt.Sprintf(floodKeyFormat, event.Team, event.Channel, event.User, command.usage, text))
+}
@@ -0,0 +1,433 @@
+package slacker
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/shomali11/proper"
+	"github.com/shomali11/slacker/format"
+	"github.com/slack-go/slack"
+)
+
+const (
+	formCallbackID       = "slacker_form"
+	formTTL              = time.Hour
+	formSubmitText       = "Submit"
+	formChoicesFormat    = "%s (%s)"
+	formDateFormat       = "%s (YYYY-MM-DD)"
+	formOptionalFormat   = "%s, or reply _%s_ to leave it empty"
+	formSkipAnswer       = "skip"
+	responseActionErrors = "errors"
+	requiredField        = "is required"
+	notANumber           = "must be a number"
+	notADate             = "must be a date formatted as YYYY-MM-DD"
+	formInProgress       = "form in progress"
+)
+
+// FieldType is the kind of value a form field collects
+type FieldType int
+
+const (
+	// TextField collects free text
+	TextField FieldType = iota
+
+	// NumberField collects a number
+	NumberField
+
+	// DateField collects a date
+	DateField
+
+	// ChoiceField collects one of the field's choices
+	ChoiceField
+)
+
+// FormField is a value a form collects
+type FormField struct {
+	Name       string
+	Label      string
+	Type       FieldType
+	Choices    []string
+	Optional   bool
+	Validators []Validator
+}
+
+// NewForm creates a form with the title, to which fields are added in the order they are asked
+func NewForm(title string) *Form {
+	return &Form{Title: title}
+}
+
+// Form collects structured input from a user, as a modal when interactivity is available
+// or as a conversation in direct messages otherwise
+type Form struct {
+	// Title heads the modal, limited to 24 characters by Slack
+	Title string
+
+	// Fields are asked in order
+	Fields []*FormField
+}
+
+// Text adds a field collecting free text
+func (f *Form) Text(name string, label string) *Form {
+	return f.add(&FormField{Name: name, Label: label, Type: TextField})
+}
+
+// Number adds a field collecting a number
+func (f *Form) Number(name string, label string) *Form {
+	return f.add(&FormField{Name: name, Label: label, Type: NumberField})
+}
+
+// Date adds a field collecting a date
+func (f *Form) Date(name string, label string) *Form {
+	return f.add(&FormField{Name: name, Label: label, Type: DateField})
+}
+
+// Choice adds a field collecting one of the choices
+func (f *Form) Choice(name string, label string, choices ...string) *Form {
+	return f.add(&FormField{Name: name, Label: label, Type: ChoiceField, Choices: choices})
+}
+
+// Optional marks the field as one the user can leave empty
+func (f *Form) Optional(name string) *Form {
+	for _, field := range f.Fields {
+		if field.Name == name {
+			field.Optional = true
+		}
+	}
+	return f
+}
+
+// Validate adds a validator for the values given for the field
+func (f *Form) Validate(name string, validator Validator) *Form {
+	for _, field := range f.Fields {
+		if field.Name == name {
+			field.Validators = append(field.Validators, validator)
+		}
+	}
+	return f
+}
+
+func (f *Form) add(field *FormField) *Form {
+	f.Fields = append(f.Fields, field)
+	return f
+}
+
+// validate checks the value given for the field, returning why it is invalid
+func (field *FormField) validate(value string) error {
+	if len(value) == 0 {
+		if field.Optional {
+			return nil
+		}
+		return errors.New(requiredField)
+	}
+
+	switch field.Type {
+	case NumberField:
+		_, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return errors.New(notANumber)
+		}
+
+	case DateField:
+		_, err := time.Parse(dateLayout, value)
+		if err != nil {
+			return errors.New(notADate)
+		}
+
+	case ChoiceField:
+		err := OneOf(field.Choices...)(value)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, validator := range field.Validators {
+		err := validator(value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// question returns how the field is asked in direct messages
+func (field *FormField) question() string {
+	question := format.Bold(field.Label)
+	switch field.Type {
+	case DateField:
+		question = fmt.Sprintf(formDateFormat, question)
+
+	case ChoiceField:
+		question = fmt.Sprintf(formChoicesFormat, question, strings.Join(field.Choices, choiceSeparator))
+	}
+
+	if field.Optional {
+		return fmt.Sprintf(formOptionalFormat, question, formSkipAnswer)
+	}
+	return question
+}
+
+// element returns the input element collecting the field in a modal
+func (field *FormField) element() slack.BlockElement {
+	switch field.Type {
+	case DateField:
+		element := slack.NewDatePickerBlockElement(field.Name)
+		element.Placeholder = plainTextObject(field.Label)
+		return element
+
+	case ChoiceField:
+		options := make([]*slack.OptionBlockObject, 0, len(field.Choices))
+		for _, choice := range field.Choices {
+			options = append(options, slack.NewOptionBlockObject(choice, plainTextObject(choice), nil))
+		}
+		return slack.NewOptionsSelectBlockElement(slack.OptTypeStatic, plainTextObject(field.Label), field.Name, options...)
+	}
+	return slack.NewPlainTextInputBlockElement(nil, field.Name)
+}
+
+// FormAnswers are the values a user gave for the fields of a form
+type FormAnswers struct {
+	UserID     string
+	properties *proper.Properties
+}
+
+// Param attempts to look up a string value by key. If not found, return the an empty string
+func (a *FormAnswers) Param(key string) string {
+	return a.StringParam(key, empty)
+}
+
+// StringParam attempts to look up a string value by key. If not found, return the default string value
+func (a *FormAnswers) StringParam(key string, defaultValue string) string {
+	return a.properties.StringParam(key, defaultValue)
+}
+
+// IntegerParam attempts to look up a integer value by key. If not found, return the default integer value
+func (a *FormAnswers) IntegerParam(key string, defaultValue int) int {
+	return a.properties.IntegerParam(key, defaultValue)
+}
+
+// FloatParam attempts to look up a float value by key. If not found, return the default float value
+func (a *FormAnswers) FloatParam(key string, defaultValue float64) float64 {
+	return a.properties.FloatParam(key, defaultValue)
+}
+
+// Date returns the date given for the field, at midnight UTC, or the zero time if it was left empty
+func (a *FormAnswers) Date(key string) time.Time {
+	date, _ := parsePicked(a.Param(key), empty, time.UTC)
+	return date
+}
+
+// formSession is a form being filled in by a user
+type formSession struct {
+	form    *Form
+	handler func(answers *FormAnswers, response ResponseWriter)
+	userID  string
+	channel string
+	direct  string
+	answers map[string]string
+	expiry  time.Time
+}
+
+// viewErrorsResponse rejects a view submission, showing the errors under the blocks
+type viewErrorsResponse struct {
+	ResponseAction string            `json:"response_action"`
+	Errors         map[string]string `json:"errors"`
+}
+
+// StartForm asks the user who sent the request to fill in the form, then calls the handler with the answers,
+// replying to the channel the request came from. The form is a modal when started from a slash command
+// with the InteractionsHandler served, and a conversation in direct messages otherwise.
+// Forms are kept in memory for an hour. A conversation is not started while the user is filling in another one,
+// failing with ErrFormInProgress
+func (s *Slacker) StartForm(request *Request, form *Form, handler func(answers *FormAnswers, response ResponseWriter)) error {
+	session := &formSession{form: form, handler: handler, userID: request.Event.User, channel: request.Event.Channel, answers: make(map[string]string)}
+	if len(form.Fields) == 0 {
+		s.completeForm(session)
+		return nil
+	}
+
+	if s.isInteractive() && request.SlashCommand != nil && len(request.SlashCommand.TriggerID) > 0 {
+		id, _ := s.addFormSession(session)
+		return s.callAPI(viewsOpenMethod, &viewOpenPayload{TriggerID: request.SlashCommand.TriggerID, View: form.modal(id)}, nil)
+	}
+
+	channel, err := s.directChannel(request.Event.User)
+	if err != nil {
+		return err
+	}
+
+	session.direct = channel
+	_, err = s.addFormSession(session)
+	if err != nil {
+		return err
+	}
+	return s.postMessage(channel, form.Fields[0].question())
+}
+
+// modal returns the modal collecting the form's fields, identified by the ID
+func (f *Form) modal(id string) *slack.ModalViewRequest {
+	view := &slack.ModalViewRequest{
+		Type:            slack.VTModal,
+		CallbackID:      formCallbackID,
+		Title:           plainTextObject(f.Title),
+		Submit:          plainTextObject(formSubmitText),
+		PrivateMetadata: id,
+	}
+
+	for _, field := range f.Fields {
+		block := slack.NewInputBlock(field.Name, plainTextObject(field.Label), nil, field.element())
+		block.Optional = field.Optional
+		view.Blocks.BlockSet = append(view.Blocks.BlockSet, block)
+	}
+	return view
+}
+
+// isInteractive determines whether the InteractionsHandler is served
+func (s *Slacker) isInteractive() bool {
+	return atomic.LoadInt32(&s.interactive) == 1
+}
+
+// addFormSession remembers the session, by user for conversations and by a new ID for modals, returning the key.
+// It fails with ErrFormInProgress when the user is already filling in a conversation
+func (s *Slacker) addFormSession(session *formSession) (string, error) {
+	s.formsMutex.Lock()
+	defer s.formsMutex.Unlock()
+
+	now := time.Now()
+	for key, session := range s.forms {
+		if now.After(session.expiry) {
+			delete(s.forms, key)
+		}
+	}
+
+	key := session.userID
+	if len(session.direct) == 0 {
+		s.formID++
+		key = strconv.Itoa(s.formID)
+	}
+
+	_, ok := s.forms[key]
+	if ok {
+		return empty, ErrFormInProgress
+	}
+
+	session.expiry = now.Add(formTTL)
+	s.forms[key] = session
+	return key, nil
+}
+
+// removeFormSession forgets the session with the key, returning it if it had not expired
+func (s *Slacker) removeFormSession(key string) *formSession {
+	s.formsMutex.Lock()
+	defer s.formsMutex.Unlock()
+
+	session, ok := s.forms[key]
+	delete(s.forms, key)
+	if !ok || time.Now().After(session.expiry) {
+		return nil
+	}
+	return session
+}
+
+// collectFormAnswer records the message as the value of the field the user was last asked, asking the next one.
+// It returns whether the message was an answer, in which case it is not matched against the commands
+func (s *Slacker) collectFormAnswer(event *slack.MessageEvent) bool {
+	s.formsMutex.Lock()
+	session, ok := s.forms[event.User]
+	if !ok || session.direct != event.Channel || time.Now().After(session.expiry) {
+		s.formsMutex.Unlock()
+		return false
+	}
+
+	reply, completed := session.answer(event.Text)
+	if completed {
+		delete(s.forms, event.User)
+	}
+	s.formsMutex.Unlock()
+
+	if completed {
+		s.completeForm(session)
+		return true
+	}
+
+	s.postMessage(event.Channel, reply)
+	return true
+}
+
+// answer records the text as the value of the field the user was last asked, returning the next question
+// or why the value is invalid, or whether all the fields were answered. The forms mutex must be held
+func (session *formSession) answer(text string) (string, bool) {
+	field := session.form.Fields[len(session.answers)]
+	value := strings.TrimSpace(text)
+	if field.Optional && strings.EqualFold(value, formSkipAnswer) {
+		value = empty
+	}
+
+	err := field.validate(value)
+	if err != nil {
+		return fmt.Sprintf(errorFormat, err.Error()) + newLine + field.question(), false
+	}
+
+	session.answers[field.Name] = value
+	if len(session.answers) < len(session.form.Fields) {
+		return session.form.Fields[len(session.answers)].question(), false
+	}
+	return empty, true
+}
+
+// handleFormSubmission validates the values submitted with a form's modal, returning the errors shown in the modal,
+// or else closing it and calling the form's handler
+func (s *Slacker) handleFormSubmission(payload *interaction) *viewErrorsResponse {
+	s.formsMutex.Lock()
+	session, ok := s.forms[payload.View.PrivateMetadata]
+	s.formsMutex.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	answers := make(map[string]string)
+	errs := make(map[string]string)
+	for _, field := range session.form.Fields {
+		value := empty
+		state := payload.View.State.Values[field.Name][field.Name]
+		switch {
+		case state == nil:
+		case state.SelectedOption != nil:
+			value = state.SelectedOption.Value
+		case len(state.SelectedDate) > 0:
+			value = state.SelectedDate
+		default:
+			value = state.Value
+		}
+
+		err := field.validate(value)
+		if err != nil {
+			errs[field.Name] = err.Error()
+		}
+		answers[field.Name] = value
+	}
+
+	if len(errs) > 0 {
+		return &viewErrorsResponse{ResponseAction: responseActionErrors, Errors: errs}
+	}
+
+	if s.removeFormSession(payload.View.PrivateMetadata) == nil {
+		return nil
+	}
+
+	session.answers = answers
+	s.goHandle(func() {
+		s.completeForm(session)
+	})
+	return nil
+}
+
+// completeForm calls the form's handler with the answers
+func (s *Slacker) completeForm(session *formSession) {
+	answers := &FormAnswers{UserID: session.userID, properties: proper.NewProperties(session.answers)}
+	session.handler(answers, newAPIResponse(session.channel, s))
+}
@@ -0,0 +1,77 @@
+// Package format provides helpers formatting text with Slack's markup
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	boldFormat      = "*%s*"
+	italicFormat    = "_%s_"
+	strikeFormat    = "~%s~"
+	codeFormat      = "`%s`"
+	codeBlockFormat = "```\n%s\n```"
+	quotePrefix     = "> "
+	newLine         = "\n"
+	linkFormat      = "<%s|%s>"
+	plainLinkFormat = "<%s>"
+	mentionFormat   = "<@%s>"
+	channelFormat   = "<#%s>"
+)
+
+// escaper replaces the characters Slack reserves for its markup with their HTML entities
+var escaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// Escape escapes &, < and > so text provided by users can be echoed without being interpreted as mentions or links
+func Escape(text string) string {
+	return escaper.Replace(text)
+}
+
+// Bold formats the text in bold
+func Bold(text string) string {
+	return fmt.Sprintf(boldFormat, text)
+}
+
+// Italic formats the text in italics
+func Italic(text string) string {
+	return fmt.Sprintf(italicFormat, text)
+}
+
+// Strike formats the text with a strikethrough
+func Strike(text string) string {
+	return fmt.Sprintf(strikeFormat, text)
+}
+
+// Code formats the text as inline code
+func Code(text string) string {
+	return fmt.Sprintf(codeFormat, text)
+}
+
+// CodeBlock formats the text as a block of code
+func CodeBlock(text string) string {
+	return fmt.Sprintf(codeBlockFormat, text)
+}
+
+// Quote formats every line of the text as a quote
+func Quote(text string) string {
+	return quotePrefix + strings.Replace(text, newLine, newLine+quotePrefix, -1)
+}
+
+// Link formats a link to the URL displaying the text, or the URL itself if the text is empty
+func Link(url string, text string) string {
+	if len(text) == 0 {
+		return fmt.Sprintf(plainLinkFormat, url)
+	}
+	return fmt.Sprintf(linkFormat, url, text)
+}
+
+// Mention formats a mention of the user with the ID
+func Mention(userID string) string {
+	return fmt.Sprintf(mentionFormat, userID)
+}
+
+// Channel formats a link to the channel with the ID
+func Channel(channelID string) string {
+	return fmt.Sprintf(channelFormat, channelID)
+}
@@ -0,0 +1,74 @@
+package slacker
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/shomali11/proper"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingResponse remembers the replies instead of sending them
+type recordingResponse struct {
+	replies []string
+	errs    []error
+}
+
+func (r *recordingResponse) Reply(text string, options ...ReplyOption) {
+	r.replies = append(r.replies, text)
+}
+
+func (r *recordingResponse) ReportError(err error) {
+	r.errs = append(r.errs, err)
+}
+
+func (r *recordingResponse) Typing() {}
+
+func TestHelpText(t *testing.T) {
+	tests := []struct {
+		name    string
+		command *BotCommand
+		help    string
+	}{
+		{name: "keywords", command: NewBotCommand("ping", "Ping the bot", nil), help: "*ping* - _Ping the bot_"},
+		{name: "parameters", command: NewBotCommand("echo <word!> <times>", "Echo a word", nil), help: "*echo* `<word>` `[times]` - _Echo a word_"},
+		{name: "described parameter", command: NewBotCommand("echo <word>", "Echo a word", nil, WithParamDescription("word", "What to echo")), help: "*echo* `[word]` - _Echo a word_\n" + paramIndent + "`word` - What to echo"},
+		{name: "custom help", command: NewBotCommand("echo <word>", "Echo a word", nil, WithHelp("Echoes whatever it is told")), help: "Echoes whatever it is told"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.help, test.command.helpText())
+		})
+	}
+}
+
+func TestDefaultHelp(t *testing.T) {
+	tests := []struct {
+		name  string
+		topic string
+		reply string
+	}{
+		{name: "all commands", reply: "*help* `[command...]` - _help_\n*ping* - _Ping the bot_\n*deploy* `<env:dev|prod>` - _Deploy the app_\n"},
+		{name: "topic", topic: "deploy", reply: "*deploy* `<env:dev|prod>` - _Deploy the app_\n"},
+		{name: "unknown topic", topic: "launch", reply: fmt.Sprintf(unknownHelpTopic, "`launch`")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bot := NewClient("xoxb-test", WithHelpTriggers("help", "aide"))
+			bot.Command("ping", "Ping the bot", nil)
+			bot.Command("deploy <env!:dev|prod>", "Deploy the app", nil)
+			bot.prependHelpHandle()
+
+			properties := proper.NewProperties(map[string]string{helpTopicParam: test.topic})
+			request := NewRequest(context.Background(), &slack.MessageEvent{}, properties)
+			response := &recordingResponse{}
+			bot.defaultHelp(request, response)
+
+			assert.Equal(t, []string{test.reply}, response.replies)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package slacker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shomali11/slacker/format"
+)
+
+const (
+	defaultHelpThreadThreshold = 0
+	helpPageSize               = 10
+	helpIndexFormat            = "*%d commands*, details in the thread: %s"
+	helpIndexSeparator         = ", "
+)
+
+// replyHelpThread replies a short index of the commands, then their help in pages threaded under it.
+// It returns false without replying when the response cannot report the index's timestamp,
+// as the pages would follow it in the channel
+func (s *Slacker) replyHelpThread(commands []*BotCommand, response ResponseWriter) bool {
+	tracker, ok := response.(postTracker)
+	if !ok {
+		return false
+	}
+
+	var timestamp string
+	untrack, ok := tracker.trackPosts(func(posted string) {
+		if len(timestamp) == 0 {
+			timestamp = posted
+		}
+	})
+	if !ok {
+		return false
+	}
+
+	usages := make([]string, 0, len(commands))
+	for _, command := range commands {
+		usages = append(usages, format.Code(command.usage))
+	}
+	response.Reply(fmt.Sprintf(helpIndexFormat, len(commands), strings.Join(usages, helpIndexSeparator)))
+	untrack()
+
+	// The index was not posted, so the help follows in a single message
+	if len(timestamp) == 0 {
+		response.Reply(helpMessage(commands))
+		return true
+	}
+
+	for start := 0; start < len(commands); start += helpPageSize {
+		end := start + helpPageSize
+		if end > len(commands) {
+			end = len(commands)
+		}
+		response.Reply(helpMessage(commands[start:end]), WithThreadTimestamp(timestamp))
+	}
+	return true
+}
+
+// helpMessage lists the help of the commands, one per line
+func helpMessage(commands []*BotCommand) string {
+	message := empty
+	for _, command := range commands {
+		message += command.helpText() + newLine
+	}
+	return message
+}
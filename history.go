@@ -0,0 +1,83 @@
+package slacker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	historyKeyFormat   = "slacker:history:%s:%s:%s"
+	defaultHistorySize = 10
+)
+
+// HistoryMessage is a message previously sent to the bot by a user in a channel
+type HistoryMessage struct {
+	Text      string `json:"text"`
+	Timestamp string `json:"ts"`
+}
+
+// loadHistory loads the messages stored under the key, oldest first
+func loadHistory(store Store, key string) ([]*HistoryMessage, error) {
+	data, ok, err := store.Get(key)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	var history []*HistoryMessage
+	err = json.Unmarshal(data, &history)
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// recordHistory appends the message to the history of its user in its channel, keeping the most recent ones
+func (s *Slacker) recordHistory(ctx context.Context, event *slack.MessageEvent) {
+	if s.defaults.HistorySize <= 0 {
+		return
+	}
+
+	store := s.sessionStore()
+	key := historyKey(event.Team, event.Channel, event.User)
+
+	history, err := loadHistory(store, key)
+	if err != nil {
+		s.reportRequestError(ctx, err)
+		return
+	}
+
+	history = appendHistory(history, &HistoryMessage{Text: event.Text, Timestamp: event.Timestamp})
+	if len(history) > s.defaults.HistorySize {
+		history = history[len(history)-s.defaults.HistorySize:]
+	}
+
+	data, err := json.Marshal(history)
+	if err != nil {
+		s.reportRequestError(ctx, err)
+		return
+	}
+
+	err = store.Set(key, data, s.defaults.SessionTTL)
+	if err != nil {
+		s.reportRequestError(ctx, err)
+	}
+}
+
+// appendHistory appends the message, replacing it instead if it is already there, as with edits and replays
+func appendHistory(history []*HistoryMessage, message *HistoryMessage) []*HistoryMessage {
+	for i, previous := range history {
+		if previous.Timestamp == message.Timestamp {
+			history[i] = message
+			return history
+		}
+	}
+	return append(history, message)
+}
+
+// historyKey returns the key of the message history of the user in the channel
+func historyKey(teamID string, channelID string, userID string) string {
+	return fmt.Sprintf(historyKeyFormat, teamID, channelID, userID)
+}
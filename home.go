@@ -0,0 +1,55 @@
+package slacker
+
+import (
+	"encoding/json"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	appHomeOpenedEventType = "app_home_opened"
+	viewsPublishMethod     = "views.publish"
+)
+
+// AppHomeOpenedEvent is received when a user opens the bot's App Home
+type AppHomeOpenedEvent struct {
+	User           string `json:"user"`
+	Channel        string `json:"channel"`
+	Tab            string `json:"tab"`
+	EventTimestamp string `json:"event_ts"`
+}
+
+// NewHomeView creates a Home tab view made of the blocks
+func NewHomeView(blocks ...slack.Block) *slack.HomeTabViewRequest {
+	return &slack.HomeTabViewRequest{Type: slack.VTHomeTab, Blocks: slack.Blocks{BlockSet: blocks}}
+}
+
+// homeViewPayload is the payload accepted by views.publish
+type homeViewPayload struct {
+	UserID string                    `json:"user_id"`
+	View   *slack.HomeTabViewRequest `json:"view"`
+}
+
+// OnAppHomeOpened handle users opening the bot's App Home, requires the Events API
+func (s *Slacker) OnAppHomeOpened(appHomeOpenedHandler func(event *AppHomeOpenedEvent)) {
+	s.appHomeOpenedHandler = appHomeOpenedHandler
+}
+
+// PublishHomeView publishes the view to the user's Home tab
+func (s *Slacker) PublishHomeView(userID string, view *slack.HomeTabViewRequest) error {
+	return s.callAPI(viewsPublishMethod, &homeViewPayload{UserID: userID, View: view}, nil)
+}
+
+func (s *Slacker) handleAppHomeOpened(data json.RawMessage) {
+	if s.appHomeOpenedHandler == nil {
+		return
+	}
+
+	event := &AppHomeOpenedEvent{}
+	err := json.Unmarshal(data, event)
+	if err != nil {
+		s.reportError(err)
+		return
+	}
+	s.appHomeOpenedHandler(event)
+}
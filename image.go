@@ -0,0 +1,11 @@
+package slacker
+
+import (
+	"io"
+)
+
+// postImage uploads the PNG image to the channel, in the thread set by the reply options if any,
+// with the caption as the message sharing it
+func (s *Slacker) postImage(channel string, name string, png io.Reader, caption string, defaults *ReplyDefaults) error {
+	return s.uploadFile(channel, name, empty, png, caption, defaults.ThreadTimestamp)
+}
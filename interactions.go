@@ -0,0 +1,196 @@
+package slacker
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	payloadParameter = "payload"
+	viewSubmission   = "view_submission"
+	blockActions     = "block_actions"
+	viewsOpenMethod  = "views.open"
+)
+
+// ViewStateValue contains the value of an input element submitted with a view
+type ViewStateValue struct {
+	Type           string                   `json:"type"`
+	Value          string                   `json:"value"`
+	SelectedOption *slack.OptionBlockObject `json:"selected_option"`
+	SelectedDate   string                   `json:"selected_date"`
+	SelectedTime   string                   `json:"selected_time"`
+}
+
+// Time returns the date or time picked with a date or time picker, in UTC.
+// Dates are at midnight, while times are on the current date
+func (v *ViewStateValue) Time() (time.Time, error) {
+	return v.TimeIn(time.UTC)
+}
+
+// TimeIn returns the date or time picked with a date or time picker, in the location, such as the user's
+// from Request.UserLocation. Dates are at midnight, while times are on the current date in the location
+func (v *ViewStateValue) TimeIn(location *time.Location) (time.Time, error) {
+	return parsePicked(v.SelectedDate, v.SelectedTime, location)
+}
+
+// PickedDateTime combines the date and the time picked with a date picker and a time picker of the same view,
+// in the location, such as the user's from Request.UserLocation
+func PickedDateTime(date *ViewStateValue, clock *ViewStateValue, location *time.Location) (time.Time, error) {
+	return parsePicked(date.SelectedDate, clock.SelectedTime, location)
+}
+
+// interaction is the payload Slack posts to the interactivity request URL
+type interaction struct {
+	Type         string               `json:"type"`
+	CallbackID   string               `json:"callback_id"`
+	TriggerID    string               `json:"trigger_id"`
+	ResponseURL  string               `json:"response_url"`
+	User         interactionUser      `json:"user"`
+	Team         interactionTeam      `json:"team"`
+	View         *submittedView       `json:"view"`
+	WorkflowStep *workflowStepPayload `json:"workflow_step"`
+	Actions      []*interactionAction `json:"actions"`
+	Container    interactionContainer `json:"container"`
+}
+
+// interactionAction is an interactive element the user acted on
+type interactionAction struct {
+	ActionID       string                   `json:"action_id"`
+	BlockID        string                   `json:"block_id"`
+	Value          string                   `json:"value"`
+	SelectedOption *slack.OptionBlockObject `json:"selected_option"`
+	SelectedDate   string                   `json:"selected_date"`
+	SelectedTime   string                   `json:"selected_time"`
+}
+
+// interactionContainer identifies the message or view holding the element acted on
+type interactionContainer struct {
+	Type      string `json:"type"`
+	ChannelID string `json:"channel_id"`
+	MessageTs string `json:"message_ts"`
+}
+
+// interactionUser is the user who triggered an interaction
+type interactionUser struct {
+	ID string `json:"id"`
+}
+
+// interactionTeam is the workspace an interaction happened in
+type interactionTeam struct {
+	ID string `json:"id"`
+}
+
+// submittedView is a view along with the state of its input elements
+type submittedView struct {
+	ID              string    `json:"id"`
+	Type            string    `json:"type"`
+	CallbackID      string    `json:"callback_id"`
+	PrivateMetadata string    `json:"private_metadata"`
+	State           viewState `json:"state"`
+}
+
+// viewState contains the values of a view's input elements by block and action ID
+type viewState struct {
+	Values map[string]map[string]*ViewStateValue `json:"values"`
+}
+
+// viewOpenPayload is the payload accepted by views.open
+type viewOpenPayload struct {
+	TriggerID string      `json:"trigger_id"`
+	View      interface{} `json:"view"`
+}
+
+// InteractionsHandler returns an http.Handler serving Slack's interactivity request URL.
+// Interactions are acknowledged immediately, well within Slack's three seconds, and handled asynchronously.
+// Once the bot is closed, requests are rejected
+func (s *Slacker) InteractionsHandler() http.Handler {
+	s.start()
+	atomic.StoreInt32(&s.interactive, 1)
+
+	return s.VerifySignature(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if s.isClosed() {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		s.serveInteractions(writer, request)
+	}))
+}
+
+func (s *Slacker) serveInteractions(writer http.ResponseWriter, request *http.Request) {
+	payload := &interaction{}
+	err := json.Unmarshal([]byte(request.FormValue(payloadParameter)), payload)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	acknowledgment := s.acceptInteraction(payload)
+	if acknowledgment == nil {
+		writer.WriteHeader(http.StatusOK)
+		return
+	}
+
+	writer.Header().Set(contentTypeHeader, jsonContentType)
+	err = json.NewEncoder(writer).Encode(acknowledgment)
+	if err != nil {
+		s.reportError(err)
+	}
+}
+
+// acceptInteraction starts handling the interaction, returning the payload it is acknowledged with, if any.
+// Forms are validated before acknowledging, so that errors are shown in the modal
+func (s *Slacker) acceptInteraction(payload *interaction) interface{} {
+	if payload.Type == viewSubmission && payload.View != nil && payload.View.CallbackID == formCallbackID {
+		errs := s.handleFormSubmission(payload)
+		if errs == nil {
+			return nil
+		}
+		return errs
+	}
+
+	s.goHandle(func() {
+		s.handleInteraction(payload)
+	})
+	return nil
+}
+
+func (s *Slacker) handleInteraction(payload *interaction) {
+	switch payload.Type {
+	case workflowStepEdit:
+		s.handleWorkflowStepEdit(payload)
+
+	case blockActions:
+		for _, action := range payload.Actions {
+			s.handleBlockAction(payload, action)
+		}
+
+	case viewSubmission:
+		if payload.View == nil {
+			return
+		}
+
+		if payload.View.Type == workflowStepViewType {
+			s.handleWorkflowStepSave(payload)
+		}
+	}
+}
+
+func (s *Slacker) handleBlockAction(payload *interaction, action *interactionAction) {
+	switch action.ActionID {
+	case paginatorPreviousAction, paginatorNextAction:
+		s.handlePaginatorAction(payload, action)
+
+	case pollVoteAction:
+		s.handlePollButton(payload, action)
+
+	case helpSelectAction:
+		s.handleHelpSelection(payload, action)
+
+	default:
+		s.routeAction(payload, action)
+	}
+}
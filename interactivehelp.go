@@ -0,0 +1,203 @@
+package slacker
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/shomali11/slacker/format"
+	"github.com/slack-go/slack"
+)
+
+const (
+	helpSelectAction    = "slacker_help_select"
+	helpSelectText      = "Pick a command to learn more about it"
+	helpPlaceholder     = "Commands"
+	uncategorized       = "Other"
+	helpParamsFormat    = "*Parameters:* %s"
+	helpExamplesHeading = "*Examples:*"
+	paramSeparator      = ", "
+	helpBlockFormat     = "slacker_help:%s:%d"
+	helpBlockSeparator  = ":"
+	maxMenuOptions      = 100
+	maxOptionLength     = 75
+	ellipsis            = "…"
+)
+
+// InteractiveHelp is a help handler posting a select menu of the commands, grouped by category,
+// which shows the details of the command picked. Use it with Help instead of the default wall of text.
+// Only the user who asked for the help can pick a command, and more than 100 commands are split in several menus.
+// It requires the InteractionsHandler to be served
+func (s *Slacker) InteractiveHelp(request *Request, response ResponseWriter) {
+	_, err := s.PostBlocks(request.Event.Channel, helpSelectText, s.helpMenu(request.Event.User, s.allowedCommands(request.Event), nil)...)
+	if err != nil {
+		response.ReportError(err)
+	}
+}
+
+// helpMenu returns the blocks of the help menus of the user, along with the details of the selected command, if any.
+// Commands are grouped by category, in menus of at most 100 options
+func (s *Slacker) helpMenu(userID string, commands []*BotCommand, selected *BotCommand) []slack.Block {
+	var categories []string
+	categorized := make(map[string][]*BotCommand)
+	for _, command := range commands {
+		category := command.category
+		if len(category) == 0 {
+			category = uncategorized
+		}
+
+		_, ok := categorized[category]
+		if !ok {
+			categories = append(categories, category)
+		}
+		categorized[category] = append(categorized[category], command)
+	}
+
+	var sorted []*BotCommand
+	for _, category := range categories {
+		sorted = append(sorted, categorized[category]...)
+	}
+
+	var blocks []slack.Block
+	for start := 0; start < len(sorted); start += maxMenuOptions {
+		end := start + maxMenuOptions
+		if end > len(sorted) {
+			end = len(sorted)
+		}
+
+		section := markdownSection(helpSelectText)
+		section.BlockID = fmt.Sprintf(helpBlockFormat, userID, len(blocks))
+		section.Accessory = slack.NewAccessory(helpSelect(sorted[start:end], selected, len(categories) > 1))
+		blocks = append(blocks, section)
+	}
+
+	if selected != nil {
+		blocks = append(blocks, slack.NewDividerBlock(), markdownSection(commandDetails(selected)))
+	}
+	return blocks
+}
+
+// helpSelect returns a select menu of the commands, grouped by category if asked to
+func helpSelect(commands []*BotCommand, selected *BotCommand, grouped bool) *slack.SelectBlockElement {
+	menu := slack.NewOptionsSelectBlockElement(slack.OptTypeStatic, plainTextObject(helpPlaceholder), helpSelectAction)
+
+	var groups []*slack.OptionGroupBlockObject
+	for i, command := range commands {
+		option := slack.NewOptionBlockObject(helpOptionValue(command), plainTextObject(truncate(command.usage, maxOptionLength)), nil)
+		if command == selected {
+			menu.InitialOption = option
+		}
+
+		if !grouped {
+			menu.Options = append(menu.Options, option)
+			continue
+		}
+
+		if i == 0 || command.category != commands[i-1].category {
+			category := command.category
+			if len(category) == 0 {
+				category = uncategorized
+			}
+			groups = append(groups, slack.NewOptionGroupBlockElement(plainTextObject(truncate(category, maxOptionLength))))
+		}
+		group := groups[len(groups)-1]
+		group.Options = append(group.Options, option)
+	}
+
+	menu.OptionGroups = groups
+	return menu
+}
+
+// helpOptionValue identifies the command in the menu, with a hash of its usage when too long to be the value
+func helpOptionValue(command *BotCommand) string {
+	if len(command.usage) <= maxOptionLength {
+		return command.usage
+	}
+
+	hash := sha1.Sum([]byte(command.usage))
+	return hex.EncodeToString(hash[:])
+}
+
+// truncate shortens the text to the number of characters, ending it with an ellipsis when cut
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-1]) + ellipsis
+}
+
+func (s *Slacker) handleHelpSelection(payload *interaction, action *interactionAction) {
+	if action.SelectedOption == nil {
+		return
+	}
+
+	// Only the user who asked for the help can pick a command, as the menu is shared with the channel
+	fields := strings.Split(action.BlockID, helpBlockSeparator)
+	if len(fields) < 2 || fields[1] != payload.User.ID {
+		return
+	}
+
+	// The menu is shown again with the commands the user who picked one is allowed to use
+	event := &slack.MessageEvent{}
+	event.User = payload.User.ID
+	event.Channel = payload.Container.ChannelID
+	event.Team = payload.Team.ID
+	commands := s.allowedCommands(event)
+
+	var selected *BotCommand
+	for _, command := range commands {
+		if helpOptionValue(command) == action.SelectedOption.Value {
+			selected = command
+			break
+		}
+	}
+
+	if selected == nil {
+		return
+	}
+
+	blocks := s.helpMenu(payload.User.ID, commands, selected)
+	err := s.UpdateBlocks(payload.Container.ChannelID, payload.Container.MessageTs, helpSelectText, blocks...)
+	if err != nil {
+		s.reportError(err)
+	}
+}
+
+// allowedCommands returns the commands listed in the help that the user can use in the workspace and channel of the event
+func (s *Slacker) allowedCommands(event *slack.MessageEvent) []*BotCommand {
+	var commands []*BotCommand
+	for _, command := range s.botCommands {
+		if !command.hidden && s.isCommandAllowed(command, event) {
+			commands = append(commands, command)
+		}
+	}
+	return commands
+}
+
+// commandDetails describes the command's usage, parameters and examples, unless it has a custom help
+func commandDetails(command *BotCommand) string {
+	if len(command.help) > 0 {
+		return command.help
+	}
+
+	metadata := command.Metadata()
+
+	details := format.Code(metadata.Usage) + newLine + format.Italic(metadata.Description) + newLine
+	if len(metadata.Params) > 0 {
+		labels := make([]string, 0, len(metadata.Params))
+		for _, param := range metadata.Params {
+			labels = append(labels, command.paramLabel(param))
+		}
+		details += fmt.Sprintf(helpParamsFormat, strings.Join(labels, paramSeparator)) + newLine
+	}
+
+	if len(metadata.Examples) > 0 {
+		details += helpExamplesHeading + newLine
+		for _, example := range metadata.Examples {
+			details += format.Code(example) + newLine
+		}
+	}
+	return details
+}
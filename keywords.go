@@ -0,0 +1,48 @@
+package slacker
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/shomali11/proper"
+	"github.com/slack-go/slack"
+)
+
+const (
+	keywordPatternFormat = `(?i)(^|\W)%s(\W|$)`
+)
+
+// keywordTrigger is a handler fired by messages containing its keyword
+type keywordTrigger struct {
+	expression *regexp.Regexp
+	handler    func(request *Request, response ResponseWriter)
+}
+
+// OnKeyword handle messages containing the keyword as a word, ignoring case, whether the bot is mentioned or not.
+// Triggers fire before and regardless of command matching. Outside direct messages, it requires receiving
+// all the messages of the channels, as the RTM connection does or the Events API does when subscribed to them
+func (s *Slacker) OnKeyword(keyword string, handler func(request *Request, response ResponseWriter)) {
+	expression := regexp.MustCompile(fmt.Sprintf(keywordPatternFormat, regexp.QuoteMeta(keyword)))
+	s.keywordTriggers = append(s.keywordTriggers, &keywordTrigger{expression: expression, handler: handler})
+}
+
+// triggerKeywords fires the handlers of the keywords the message contains. Edits are ignored,
+// as the message already fired them when it was sent
+func (s *Slacker) triggerKeywords(ctx context.Context, event *slack.MessageEvent, response ResponseWriter) {
+	if (s.isFromBot(event) && !s.defaults.BotMessages) || event.Edited != nil {
+		return
+	}
+
+	for _, trigger := range s.keywordTriggers {
+		if trigger.expression.MatchString(event.Text) {
+			s.fireKeyword(trigger, s.newRequest(ctx, event, &proper.Properties{}), response)
+		}
+	}
+}
+
+// fireKeyword calls the handler of the trigger, recovering from its panic so the other triggers still fire
+func (s *Slacker) fireKeyword(trigger *keywordTrigger, request *Request, response ResponseWriter) {
+	defer s.recoverHandler(request, response)
+	trigger.handler(request, response)
+}
@@ -0,0 +1,49 @@
+package slacker
+
+import (
+	"sync"
+)
+
+const (
+	defaultMaintenanceMessage = "The bot is under maintenance, please try again later"
+)
+
+// maintenance is whether the bot is under maintenance and the message replied meanwhile
+type maintenance struct {
+	mutex   sync.Mutex
+	enabled bool
+	message string
+}
+
+// SetMaintenance turns maintenance mode on or off. While it is on, commands reply with the message instead of executing,
+// except admin-only commands, so admins can still operate the bot
+func (s *Slacker) SetMaintenance(enabled bool, message string) {
+	s.maintenance.mutex.Lock()
+	defer s.maintenance.mutex.Unlock()
+
+	s.maintenance.enabled = enabled
+	s.maintenance.message = message
+}
+
+// InMaintenance returns whether maintenance mode is on
+func (s *Slacker) InMaintenance() bool {
+	s.maintenance.mutex.Lock()
+	defer s.maintenance.mutex.Unlock()
+
+	return s.maintenance.enabled
+}
+
+// maintenanceMessage returns the message to reply instead of executing the command, or empty if it can be executed
+func (s *Slacker) maintenanceMessage(command *BotCommand) string {
+	s.maintenance.mutex.Lock()
+	defer s.maintenance.mutex.Unlock()
+
+	if !s.maintenance.enabled || command.adminOnly {
+		return empty
+	}
+
+	if len(s.maintenance.message) == 0 {
+		return defaultMaintenanceMessage
+	}
+	return s.maintenance.message
+}
@@ -0,0 +1,73 @@
+package slacker
+
+import (
+	"strings"
+
+	"github.com/shomali11/commander"
+	"github.com/shomali11/proper"
+	"github.com/shomali11/slacker/format"
+)
+
+const (
+	mentionSuffix = ":"
+)
+
+// matchCommand matches the text against the command, ignoring the case of its keywords unless case sensitive.
+// In strict mode, the keywords must also start the text
+func (s *Slacker) matchCommand(command *BotCommand, text string) (*proper.Properties, bool, error) {
+	stripped := s.stripMention(text)
+	return command.match(text, func(tokens []*commander.Token) bool {
+		if s.defaults.StrictMatching && !startsText(tokens, stripped, s.defaults.CaseSensitive) {
+			return false
+		}
+		return !s.defaults.CaseSensitive || hasKeywords(tokens, text)
+	})
+}
+
+// stripMention removes the mention of the bot the text starts with, if any
+func (s *Slacker) stripMention(text string) string {
+	text = strings.TrimSpace(text)
+	mention := format.Mention(s.botUserID())
+	if !strings.HasPrefix(text, mention) {
+		return text
+	}
+	return strings.TrimSpace(strings.TrimPrefix(text[len(mention):], mentionSuffix))
+}
+
+// startsText determines whether the text starts with the keywords preceding the first parameter, as whole words
+func startsText(tokens []*commander.Token, text string, caseSensitive bool) bool {
+	words := strings.Fields(text)
+	for i, token := range tokens {
+		if token.IsParameter {
+			return true
+		}
+
+		if i >= len(words) || !equalWords(words[i], token.Word, caseSensitive) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasKeywords determines whether each of the keywords is a word of the text, with the same case
+func hasKeywords(tokens []*commander.Token, text string) bool {
+	words := make(map[string]bool)
+	for _, word := range strings.Fields(text) {
+		words[word] = true
+	}
+
+	for _, token := range tokens {
+		if !token.IsParameter && !words[token.Word] {
+			return false
+		}
+	}
+	return true
+}
+
+// equalWords compares the words, ignoring case unless case sensitive
+func equalWords(a string, b string, caseSensitive bool) bool {
+	if caseSensitive {
+		return a == b
+	}
+	return strings.EqualFold(a, b)
+}
@@ -0,0 +1,40 @@
+package slacker
+
+import (
+	"github.com/slack-go/slack"
+)
+
+const (
+	chatPostMessageMethod = "chat.postMessage"
+	chatUpdateMethod      = "chat.update"
+)
+
+// blocksMessage is the payload accepted by chat.postMessage and chat.update for Block Kit messages
+type blocksMessage struct {
+	Channel   string       `json:"channel"`
+	Timestamp string       `json:"ts,omitempty"`
+	Text      string       `json:"text"`
+	Blocks    slack.Blocks `json:"blocks"`
+}
+
+// postedMessage identifies a message posted or updated through the Web API
+type postedMessage struct {
+	Channel   string `json:"channel"`
+	Timestamp string `json:"ts"`
+}
+
+// PostBlocks posts a Block Kit message to the channel, returning its timestamp.
+// The text is shown in notifications and by clients that cannot display blocks
+func (s *Slacker) PostBlocks(channel string, text string, blocks ...slack.Block) (string, error) {
+	result := &postedMessage{}
+	err := s.callAPI(chatPostMessageMethod, &blocksMessage{Channel: channel, Text: text, Blocks: slack.Blocks{BlockSet: blocks}}, result)
+	if err != nil {
+		return empty, err
+	}
+	return result.Timestamp, nil
+}
+
+// UpdateBlocks replaces the text and blocks of the message with the timestamp
+func (s *Slacker) UpdateBlocks(channel string, timestamp string, text string, blocks ...slack.Block) error {
+	return s.callAPI(chatUpdateMethod, &blocksMessage{Channel: channel, Timestamp: timestamp, Text: text, Blocks: slack.Blocks{BlockSet: blocks}}, nil)
+}
@@ -0,0 +1,80 @@
+package slacker
+
+// CommandMetadata describes a command, for tools generating documentation or app manifests
+type CommandMetadata struct {
+	Usage             string              `json:"usage"`
+	Description       string              `json:"description"`
+	Params            []string            `json:"params"`
+	Examples          []string            `json:"examples,omitempty"`
+	Category          string              `json:"category,omitempty"`
+	Channels          []string            `json:"channels,omitempty"`
+	AdminOnly         bool                `json:"admin_only,omitempty"`
+	InternalOnly      bool                `json:"internal_only,omitempty"`
+	Deprecated        bool                `json:"deprecated,omitempty"`
+	Replacement       string              `json:"replacement,omitempty"`
+	Help              string              `json:"help,omitempty"`
+	Choices           map[string][]string `json:"choices,omitempty"`
+	ParamDescriptions map[string]string   `json:"param_descriptions,omitempty"`
+	Optional          []string            `json:"optional,omitempty"`
+	Required          []string            `json:"required,omitempty"`
+	Translations      map[string]string   `json:"translations,omitempty"`
+}
+
+// CommandsMetadata returns the definitions of the commands in the order they were defined, ready to be encoded as JSON.
+// Hidden commands, such as the alternate help triggers, are left out
+func (s *Slacker) CommandsMetadata() []*CommandMetadata {
+	metadata := make([]*CommandMetadata, 0, len(s.botCommands))
+	for _, command := range s.botCommands {
+		if !command.hidden {
+			metadata = append(metadata, command.Metadata())
+		}
+	}
+	return metadata
+}
+
+// Metadata returns the definition of the command
+func (c *BotCommand) Metadata() *CommandMetadata {
+	params := []string{}
+	var optional []string
+	var required []string
+	for _, token := range c.Tokenize() {
+		if token.IsParameter {
+			params = append(params, token.Word)
+		}
+
+		if token.IsParameter && c.params.optional[token.Word] {
+			optional = append(optional, token.Word)
+		}
+
+		if token.IsParameter && c.params.required[token.Word] {
+			required = append(required, token.Word)
+		}
+	}
+
+	var translations map[string]string
+	if len(c.translations) > 0 {
+		translations = make(map[string]string)
+		for _, translation := range c.translations {
+			translations[translation.language] = translation.usage
+		}
+	}
+
+	return &CommandMetadata{
+		Usage:             c.usage,
+		Description:       c.description,
+		Params:            params,
+		Examples:          c.examples,
+		Category:          c.category,
+		Channels:          c.channels,
+		AdminOnly:         c.adminOnly,
+		InternalOnly:      c.internalOnly,
+		Deprecated:        c.deprecated,
+		Replacement:       c.replacement,
+		Help:              c.help,
+		Choices:           c.params.choices,
+		ParamDescriptions: c.paramDescriptions,
+		Optional:          optional,
+		Required:          required,
+		Translations:      translations,
+	}
+}
@@ -0,0 +1,100 @@
+package slacker
+
+import (
+	"github.com/shomali11/commander"
+)
+
+const (
+	namespaceParam = "rest"
+)
+
+// Namespace is a command routing the rest of the message to its own commands, as in "jira create <title>",
+// so a group of commands can be handled apart from the others
+type Namespace struct {
+	keyword  string
+	slacker  *Slacker
+	command  *BotCommand
+	commands []*BotCommand
+}
+
+// Namespace defines a command matching any message starting with the keyword, whose own commands are tried first.
+// When none of them matches, the namespace's commands are listed
+func (s *Slacker) Namespace(keyword string, description string, options ...CommandOption) *Namespace {
+	namespace := &Namespace{keyword: keyword, slacker: s}
+	namespace.command = NewBotCommand(keyword+space+optionalPrefix+namespaceParam+variadicSuffix+optionalSuffix, description, namespace.handle, options...)
+	s.botCommands = append(s.botCommands, namespace.command)
+	return namespace
+}
+
+// Command defines a new command in the namespace, whose usage follows the namespace's keyword.
+// It is handled, listed in the help and described in the metadata like any other command,
+// and is restricted wherever the namespace is
+func (n *Namespace) Command(usage string, description string, handler func(request *Request, response ResponseWriter), options ...CommandOption) {
+	command := NewBotCommand(n.keyword+space+usage, description, handler, options...)
+	command.inheritRestrictions(n.command)
+	n.commands = append(n.commands, command)
+
+	// The namespace's command matches whatever its commands do, so they are tried before it
+	commands := n.slacker.botCommands
+	for i, existing := range commands {
+		if existing == n.command {
+			commands = append(commands[:i], append([]*BotCommand{command}, commands[i:]...)...)
+			break
+		}
+	}
+	n.slacker.botCommands = commands
+}
+
+// handle replies to a message none of the namespace's commands matches, with the usage of the one
+// whose keywords it has, or else with the namespace's commands the user can use
+func (n *Namespace) handle(request *Request, response ResponseWriter) {
+	text := n.keyword + space + request.Param(namespaceParam)
+	startsMessage := func(tokens []*commander.Token) bool {
+		return startsText(tokens, text, n.slacker.defaults.CaseSensitive)
+	}
+
+	for _, command := range n.commands {
+		if !n.slacker.isCommandAllowed(command, request.Event) {
+			continue
+		}
+
+		_, _, err := command.match(text, startsMessage)
+		if err != nil {
+			response.Reply(command.usageError(err))
+			return
+		}
+	}
+	response.Reply(n.help(request))
+}
+
+// help lists the namespace's commands the user can use
+func (n *Namespace) help(request *Request) string {
+	help := empty
+	for _, command := range n.commands {
+		if n.slacker.isCommandAllowed(command, request.Event) {
+			help += command.helpText() + newLine
+		}
+	}
+	return help
+}
+
+// inheritRestrictions restricts the command wherever the namespace's command is
+func (c *BotCommand) inheritRestrictions(namespace *BotCommand) {
+	c.channels = append(c.channels, namespace.channels...)
+	c.teams = append(c.teams, namespace.teams...)
+	c.enterprises = append(c.enterprises, namespace.enterprises...)
+	c.adminOnly = c.adminOnly || namespace.adminOnly
+	c.internalOnly = c.internalOnly || namespace.internalOnly
+
+	if c.botMessages == nil {
+		c.botMessages = namespace.botMessages
+	}
+
+	if c.threadScope == anywhere {
+		c.threadScope = namespace.threadScope
+	}
+
+	if len(c.category) == 0 {
+		c.category = namespace.category
+	}
+}
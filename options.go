@@ -0,0 +1,96 @@
+package slacker
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	blockSuggestion = "block_suggestion"
+)
+
+// OptionsRequest is the query typed by a user in an external select menu
+type OptionsRequest struct {
+	ActionID  string
+	BlockID   string
+	Value     string
+	UserID    string
+	TeamID    string
+	ChannelID string
+}
+
+// OptionsProvider returns the options of an external select menu matching the query
+type OptionsProvider func(request *OptionsRequest) ([]*slack.OptionBlockObject, error)
+
+// optionsPayload is the payload Slack posts to the options load URL
+type optionsPayload struct {
+	Type      string               `json:"type"`
+	ActionID  string               `json:"action_id"`
+	BlockID   string               `json:"block_id"`
+	Value     string               `json:"value"`
+	User      interactionUser      `json:"user"`
+	Team      interactionTeam      `json:"team"`
+	Container interactionContainer `json:"container"`
+}
+
+// optionsResponse is the response listing the options of an external select menu
+type optionsResponse struct {
+	Options []*slack.OptionBlockObject `json:"options"`
+}
+
+// OnOptions registers the provider of the options of the external select menus with the action ID
+func (s *Slacker) OnOptions(actionID string, provider OptionsProvider) {
+	s.optionsMutex.Lock()
+	defer s.optionsMutex.Unlock()
+
+	s.optionsProviders[actionID] = provider
+}
+
+// OptionsHandler returns an http.Handler serving Slack's options load URL for external select menus.
+// Unlike other interactions, options are returned in the response, so providers must answer within three seconds
+func (s *Slacker) OptionsHandler() http.Handler {
+	return s.VerifySignature(http.HandlerFunc(s.serveOptions))
+}
+
+func (s *Slacker) serveOptions(writer http.ResponseWriter, request *http.Request) {
+	payload := &optionsPayload{}
+	err := json.Unmarshal([]byte(request.FormValue(payloadParameter)), payload)
+	if err != nil || payload.Type != blockSuggestion {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	s.optionsMutex.Lock()
+	provider, ok := s.optionsProviders[payload.ActionID]
+	s.optionsMutex.Unlock()
+
+	if !ok {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	options, err := provider(&OptionsRequest{
+		ActionID:  payload.ActionID,
+		BlockID:   payload.BlockID,
+		Value:     payload.Value,
+		UserID:    payload.User.ID,
+		TeamID:    payload.Team.ID,
+		ChannelID: payload.Container.ChannelID,
+	})
+	if err != nil {
+		s.reportError(err)
+		options = nil
+	}
+
+	if options == nil {
+		options = []*slack.OptionBlockObject{}
+	}
+
+	writer.Header().Set(contentTypeHeader, jsonContentType)
+	err = json.NewEncoder(writer).Encode(&optionsResponse{Options: options})
+	if err != nil {
+		s.reportError(err)
+	}
+}
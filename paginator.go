@@ -0,0 +1,140 @@
+package slacker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	paginatorKeyFormat      = "slacker:paginator:%s:%s"
+	paginatorPreviousAction = "slacker_paginator_previous"
+	paginatorNextAction     = "slacker_paginator_next"
+	paginatorPageFormat     = "Page %d of %d"
+	paginatorPreviousText   = "Previous"
+	paginatorNextText       = "Next"
+	paginatorTTL            = 24 * time.Hour
+	noPaginatorItems        = "there are no items to paginate"
+)
+
+// paginator is the state of a paginated message, kept in the store
+type paginator struct {
+	Items    []string `json:"items"`
+	PageSize int      `json:"page_size"`
+}
+
+// Paginate posts the first page of the items to the channel, with buttons to move between pages.
+// Clicking a button updates the message in place, which requires the InteractionsHandler to be served.
+// Blank items are skipped, as Slack rejects empty text, and there must be at least one item left
+func (s *Slacker) Paginate(channel string, items []string, pageSize int) error {
+	var nonBlank []string
+	for _, item := range items {
+		if len(strings.TrimSpace(item)) > 0 {
+			nonBlank = append(nonBlank, item)
+		}
+	}
+
+	if len(nonBlank) == 0 {
+		return errors.New(noPaginatorItems)
+	}
+	items = nonBlank
+
+	if pageSize <= 0 {
+		pageSize = len(items)
+	}
+
+	state := &paginator{Items: items, PageSize: pageSize}
+	text, blocks := state.render(0)
+
+	timestamp, err := s.PostBlocks(channel, text, blocks...)
+	if err != nil || state.pages() <= 1 {
+		return err
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return s.sessionStore().Set(paginatorKey(channel, timestamp), data, paginatorTTL)
+}
+
+func (s *Slacker) handlePaginatorAction(payload *interaction, action *interactionAction) {
+	channel := payload.Container.ChannelID
+	timestamp := payload.Container.MessageTs
+
+	data, ok, err := s.sessionStore().Get(paginatorKey(channel, timestamp))
+	if err != nil {
+		s.reportError(err)
+		return
+	}
+
+	// The state expired, leave the message as it is
+	if !ok {
+		return
+	}
+
+	state := &paginator{}
+	err = json.Unmarshal(data, state)
+	if err != nil {
+		s.reportError(err)
+		return
+	}
+
+	page, err := strconv.Atoi(action.Value)
+	if err != nil || page < 0 || page >= state.pages() {
+		return
+	}
+
+	text, blocks := state.render(page)
+	err = s.UpdateBlocks(channel, timestamp, text, blocks...)
+	if err != nil {
+		s.reportError(err)
+	}
+}
+
+// pages returns the number of pages, at least one
+func (p *paginator) pages() int {
+	if len(p.Items) == 0 {
+		return 1
+	}
+	return (len(p.Items) + p.PageSize - 1) / p.PageSize
+}
+
+// render returns the text and blocks of the page, numbered from zero
+func (p *paginator) render(page int) (string, []slack.Block) {
+	start := page * p.PageSize
+	end := start + p.PageSize
+	if end > len(p.Items) {
+		end = len(p.Items)
+	}
+
+	text := strings.Join(p.Items[start:end], newLine)
+	blocks := []slack.Block{markdownSection(text)}
+
+	pages := p.pages()
+	if pages <= 1 {
+		return text, blocks
+	}
+
+	var buttons []slack.BlockElement
+	if page > 0 {
+		buttons = append(buttons, slack.NewButtonBlockElement(paginatorPreviousAction, strconv.Itoa(page-1), plainTextObject(paginatorPreviousText)))
+	}
+
+	if page < pages-1 {
+		buttons = append(buttons, slack.NewButtonBlockElement(paginatorNextAction, strconv.Itoa(page+1), plainTextObject(paginatorNextText)))
+	}
+
+	blocks = append(blocks, markdownContext(fmt.Sprintf(paginatorPageFormat, page+1, pages)), slack.NewActionBlock(empty, buttons...))
+	return text, blocks
+}
+
+// paginatorKey returns the key of the state of the paginated message
+func paginatorKey(channel string, timestamp string) string {
+	return fmt.Sprintf(paginatorKeyFormat, channel, timestamp)
+}
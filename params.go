@@ -0,0 +1,278 @@
+package slacker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shomali11/commander"
+	"github.com/shomali11/proper"
+	"github.com/shomali11/slacker/format"
+)
+
+const (
+	invalidParamFormat = "invalid %s: %v"
+	missingParamFormat = "missing %s"
+	notOneOfFormat     = "must be one of %s"
+	usageFormat        = "*Usage:* %s"
+	choiceSeparator    = ", "
+	choicesMarker      = ":"
+	choiceDelimiter    = "|"
+	paramPrefix        = "<"
+	paramSuffix        = ">"
+	optionalPrefix     = "["
+	optionalSuffix     = "]"
+	variadicSuffix     = "..."
+	requiredSuffix     = "!"
+	paramIndent        = "    • "
+)
+
+// Validator checks the value of a parameter, returning an error describing why it is invalid
+type Validator func(value string) error
+
+// WithValidator adds a validator for the parameter. When a value given for it is invalid, the error
+// is replied along with the command's usage instead of executing the command
+func WithValidator(param string, validator Validator) CommandOption {
+	return func(command *BotCommand) {
+		if command.validators == nil {
+			command.validators = make(map[string][]Validator)
+		}
+		command.validators[param] = append(command.validators[param], validator)
+	}
+}
+
+// WithParamDescription describes the parameter, listed under the command in the help
+func WithParamDescription(param string, description string) CommandOption {
+	return func(command *BotCommand) {
+		if command.paramDescriptions == nil {
+			command.paramDescriptions = make(map[string]string)
+		}
+		command.paramDescriptions[param] = description
+	}
+}
+
+// OneOf returns a validator accepting only the values, ignoring case
+func OneOf(values ...string) Validator {
+	return func(value string) error {
+		for _, allowed := range values {
+			if strings.EqualFold(value, allowed) {
+				return nil
+			}
+		}
+		return fmt.Errorf(notOneOfFormat, strings.Join(values, choiceSeparator))
+	}
+}
+
+// paramDeclarations are what a usage declares about its parameters besides their names
+type paramDeclarations struct {
+	choices  map[string][]string
+	optional map[string]bool
+	required map[string]bool
+	variadic map[string]bool
+}
+
+// parseUsage extracts the parameters declared optional as [name], those that must be given as <name!>,
+// those taking the rest of the message as <name...> and the allowed values of those declared as <name:value|value>,
+// returning the usage with plain parameters to match the messages against
+func parseUsage(usage string) (string, *paramDeclarations) {
+	words := strings.Fields(usage)
+	declarations := &paramDeclarations{
+		choices:  make(map[string][]string),
+		optional: make(map[string]bool),
+		required: make(map[string]bool),
+		variadic: make(map[string]bool),
+	}
+
+	for i, word := range words {
+		isRequired := strings.HasPrefix(word, paramPrefix) && strings.HasSuffix(word, paramSuffix)
+		isOptional := strings.HasPrefix(word, optionalPrefix) && strings.HasSuffix(word, optionalSuffix)
+		if !isRequired && !isOptional {
+			continue
+		}
+
+		parts := strings.SplitN(word[1:len(word)-1], choicesMarker, 2)
+		declared := strings.TrimSuffix(parts[0], requiredSuffix)
+		name := strings.TrimSuffix(declared, variadicSuffix)
+		if len(parts) == 2 {
+			declarations.choices[name] = strings.Split(parts[1], choiceDelimiter)
+		}
+
+		if isOptional {
+			declarations.optional[name] = true
+		}
+
+		if isRequired && declared != parts[0] {
+			declarations.required[name] = true
+		}
+
+		if name != declared {
+			declarations.variadic[name] = true
+		}
+		words[i] = paramPrefix + name + paramSuffix
+	}
+	return strings.Join(words, space), declarations
+}
+
+// match is like Match, also returning why the message is not a valid use of the command
+// when it has the command's keywords but a parameter declared as <name!> is missing or a value is not allowed.
+// The usages are only tried when accepted, if given a function deciding it from their tokens
+func (c *BotCommand) match(text string, accepts func(tokens []*commander.Token) bool) (*proper.Properties, bool, error) {
+	var usageErr error
+	for _, pattern := range c.patterns() {
+		if accepts != nil && !accepts(pattern.Tokenize()) {
+			continue
+		}
+
+		parameters, isMatch, err := c.matchPattern(pattern, text)
+		if isMatch {
+			return parameters, true, nil
+		}
+
+		if err != nil && usageErr == nil {
+			usageErr = err
+		}
+	}
+	return nil, false, usageErr
+}
+
+// matchPattern matches the text against one of the command's usages, checking the parameters
+func (c *BotCommand) matchPattern(pattern *commander.Command, text string) (*proper.Properties, bool, error) {
+	parameters, isMatch := pattern.Match(text)
+	if !isMatch {
+		return nil, false, nil
+	}
+	parameters = c.spreadVariadic(pattern.Tokenize(), parameters)
+
+	for _, token := range pattern.Tokenize() {
+		if !token.IsParameter {
+			continue
+		}
+
+		value := parameters.StringParam(token.Word, empty)
+		if len(value) == 0 && c.params.required[token.Word] {
+			return nil, false, fmt.Errorf(missingParamFormat, token.Word)
+		}
+
+		choices, ok := c.params.choices[token.Word]
+		if !ok || len(value) == 0 {
+			continue
+		}
+
+		err := OneOf(choices...)(value)
+		if err != nil {
+			return nil, false, fmt.Errorf(invalidParamFormat, token.Word, err)
+		}
+	}
+	return parameters, true, nil
+}
+
+// spreadVariadic gives a single word to each of the parameters next to one declared as <name...>, which takes the rest,
+// as the values of consecutive parameters are otherwise split with the first ones taking all they can
+func (c *BotCommand) spreadVariadic(tokens []*commander.Token, parameters *proper.Properties) *proper.Properties {
+	if len(c.params.variadic) == 0 {
+		return parameters
+	}
+
+	values := make(map[string]string)
+	var run []string
+	spread := func() {
+		var words []string
+		isVariadic := false
+		for _, name := range run {
+			words = append(words, strings.Fields(values[name])...)
+			isVariadic = isVariadic || c.params.variadic[name]
+		}
+
+		if isVariadic {
+			for i, name := range run {
+				count := 1
+				if c.params.variadic[name] {
+					count = len(words) - (len(run) - 1 - i)
+				}
+
+				if count < 1 {
+					count = 1
+				}
+
+				if count > len(words) {
+					count = len(words)
+				}
+				values[name] = strings.Join(words[:count], space)
+				words = words[count:]
+			}
+		}
+		run = nil
+	}
+
+	for _, token := range tokens {
+		if !token.IsParameter {
+			spread()
+			continue
+		}
+
+		value := parameters.StringParam(token.Word, empty)
+		if len(value) > 0 {
+			values[token.Word] = value
+			run = append(run, token.Word)
+		}
+	}
+	spread()
+	return proper.NewProperties(values)
+}
+
+// paramLabel returns the parameter as <name> if it is required, and as [name] otherwise, along with its allowed values, if any
+func (c *BotCommand) paramLabel(param string) string {
+	label := param
+	choices, ok := c.params.choices[param]
+	if ok {
+		label += choicesMarker + strings.Join(choices, choiceDelimiter)
+	}
+
+	if c.params.variadic[param] {
+		label += variadicSuffix
+	}
+
+	if c.params.required[param] {
+		return paramPrefix + label + paramSuffix
+	}
+	return optionalPrefix + label + optionalSuffix
+}
+
+// paramsHelp returns the indented list of the described parameters, in the order of the usage
+func (c *BotCommand) paramsHelp() string {
+	text := empty
+	for _, token := range c.Tokenize() {
+		description, ok := c.paramDescriptions[token.Word]
+		if !token.IsParameter || !ok {
+			continue
+		}
+		text += newLine + paramIndent + format.Code(token.Word) + space + dash + space + description
+	}
+	return text
+}
+
+// validate runs the validators of the parameters that were given, in the order of the usage, returning the first error
+func (c *BotCommand) validate(parameters *proper.Properties) error {
+	for _, token := range c.Tokenize() {
+		if !token.IsParameter {
+			continue
+		}
+
+		value := parameters.StringParam(token.Word, empty)
+		if len(value) == 0 {
+			continue
+		}
+
+		for _, validator := range c.validators[token.Word] {
+			err := validator(value)
+			if err != nil {
+				return fmt.Errorf(invalidParamFormat, token.Word, err)
+			}
+		}
+	}
+	return nil
+}
+
+// usageError returns the reply explaining the error along with the command's usage
+func (c *BotCommand) usageError(err error) string {
+	return fmt.Sprintf(errorFormat, err.Error()) + newLine + fmt.Sprintf(usageFormat, format.Code(c.usage))
+}
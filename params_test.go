@@ -0,0 +1,65 @@
+package slacker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParamLabels(t *testing.T) {
+	tests := []struct {
+		name  string
+		usage string
+		param string
+		label string
+	}{
+		{name: "plain", usage: "echo <word>", param: "word", label: "[word]"},
+		{name: "optional", usage: "echo [word]", param: "word", label: "[word]"},
+		{name: "required", usage: "echo <word!>", param: "word", label: "<word>"},
+		{name: "variadic", usage: "echo <words...>", param: "words", label: "[words...]"},
+		{name: "required variadic", usage: "echo <words...!>", param: "words", label: "<words...>"},
+		{name: "choices", usage: "deploy <env:dev|prod>", param: "env", label: "[env:dev|prod]"},
+		{name: "required choices", usage: "deploy <env!:dev|prod>", param: "env", label: "<env:dev|prod>"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			command := NewBotCommand(test.usage, "description", nil)
+			assert.Equal(t, test.label, command.paramLabel(test.param))
+		})
+	}
+}
+
+func TestMatchParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		usage  string
+		text   string
+		match  bool
+		err    bool
+		params map[string]string
+	}{
+		{name: "single", usage: "echo <word>", text: "echo hello", match: true, params: map[string]string{"word": "hello"}},
+		{name: "missing optional", usage: "echo <word>", text: "echo", match: true, params: map[string]string{"word": ""}},
+		{name: "missing required", usage: "echo <word!>", text: "echo", err: true},
+		{name: "variadic", usage: "say <words...>", text: "say hello there", match: true, params: map[string]string{"words": "hello there"}},
+		{name: "variadic first", usage: "send <message...> <channel>", text: "send hello there general", match: true, params: map[string]string{"message": "hello there", "channel": "general"}},
+		{name: "variadic last", usage: "send <channel> <message...>", text: "send general hello there", match: true, params: map[string]string{"channel": "general", "message": "hello there"}},
+		{name: "allowed choice", usage: "deploy <env:dev|prod>", text: "deploy prod", match: true, params: map[string]string{"env": "prod"}},
+		{name: "invalid choice", usage: "deploy <env:dev|prod>", text: "deploy staging", err: true},
+		{name: "other command", usage: "echo <word>", text: "ping", match: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			command := NewBotCommand(test.usage, "description", nil)
+
+			parameters, isMatch, err := command.match(test.text, nil)
+			assert.Equal(t, test.match, isMatch)
+			assert.Equal(t, test.err, err != nil, err)
+			for param, value := range test.params {
+				assert.Equal(t, value, parameters.StringParam(param, ""), param)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package slacker
+
+import (
+	"net/url"
+)
+
+const (
+	chatGetPermalinkMethod = "chat.getPermalink"
+	messageTsField         = "message_ts"
+	noBotClient            = "request was not created by a bot"
+)
+
+// permalinkResponse is the chat.getPermalink response
+type permalinkResponse struct {
+	Permalink string `json:"permalink"`
+}
+
+// Permalink returns a link to the message with the timestamp in the channel
+func (s *Slacker) Permalink(channel string, timestamp string) (string, error) {
+	response := &permalinkResponse{}
+	err := s.queryAPI(chatGetPermalinkMethod, url.Values{channelField: {channel}, messageTsField: {timestamp}}, response)
+	if err != nil {
+		return empty, err
+	}
+	return response.Permalink, nil
+}
+
+// Permalink returns a link to the message being handled, to reference it or cross-post it
+func (r *Request) Permalink() (string, error) {
+	if r.slacker == nil {
+		return empty, ErrNoBotClient
+	}
+	return r.slacker.Permalink(r.Event.Channel, r.Event.Timestamp)
+}
@@ -0,0 +1,231 @@
+package slacker
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/shomali11/slacker/format"
+	"github.com/slack-go/slack"
+)
+
+const (
+	pollKeyFormat      = "slacker:poll:%s:%s"
+	pollVoteAction     = "slacker_poll_vote"
+	pollVoteText       = "Vote"
+	pollOptionFormat   = "%s `%d`"
+	pollReactionFormat = ":%s: %s `%d`"
+	pollTotalFormat    = "%d votes"
+	pollTTL            = 7 * 24 * time.Hour
+	tooManyPollOptions = "a poll supports at most %d options"
+)
+
+// NewPoll creates a poll asking the question, collecting votes with buttons
+func NewPoll(question string, options ...string) *Poll {
+	return &Poll{Question: question, Options: options}
+}
+
+// Poll is a question with options users vote for, each user having a single vote
+type Poll struct {
+	Question string         `json:"question"`
+	Options  []string       `json:"options"`
+	Votes    map[string]int `json:"votes"`
+
+	// Reactions collects votes with number reactions instead of buttons,
+	// for workspaces without interactivity enabled
+	Reactions bool `json:"reactions"`
+}
+
+// StartPoll posts the poll to the channel. Votes update the tally in the message, a user voting again changes their vote,
+// and removing the reaction they voted with withdraws it.
+// Polls are kept in the store for a week. Voting with buttons requires the InteractionsHandler to be served
+func (s *Slacker) StartPoll(channel string, poll *Poll) error {
+	if poll.Reactions && len(poll.Options) > len(numberEmojis) {
+		return fmt.Errorf(tooManyPollOptions, len(numberEmojis))
+	}
+
+	poll.Votes = make(map[string]int)
+	text, blocks := poll.render()
+
+	timestamp, err := s.PostBlocks(channel, text, blocks...)
+	if err != nil {
+		return err
+	}
+
+	err = s.savePoll(channel, timestamp, poll)
+	if err != nil || !poll.Reactions {
+		return err
+	}
+
+	for i := range poll.Options {
+		err := s.addReaction(numberEmojis[i], channel, timestamp)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Tally returns the number of votes of each option
+func (p *Poll) Tally() []int {
+	tally := make([]int, len(p.Options))
+	for _, option := range p.Votes {
+		if option >= 0 && option < len(tally) {
+			tally[option]++
+		}
+	}
+	return tally
+}
+
+func (s *Slacker) handlePollButton(payload *interaction, action *interactionAction) {
+	option, err := strconv.Atoi(action.Value)
+	if err != nil {
+		return
+	}
+	s.vote(payload.Container.ChannelID, payload.Container.MessageTs, payload.User.ID, option)
+}
+
+// handlePollReaction returns whether the reaction was made on a poll collecting votes with reactions
+func (s *Slacker) handlePollReaction(event *slack.ReactionAddedEvent) bool {
+	return s.handlePollReactionChange(event.Item.Channel, event.Item.Timestamp, event.User, event.Reaction, s.vote)
+}
+
+// handlePollReactionRemoved returns whether the reaction removed was made on a poll collecting votes with reactions,
+// withdrawing the vote it stood for
+func (s *Slacker) handlePollReactionRemoved(event *slack.ReactionRemovedEvent) bool {
+	return s.handlePollReactionChange(event.Item.Channel, event.Item.Timestamp, event.User, event.Reaction, s.unvote)
+}
+
+// handlePollReactionChange applies the change to the vote of the option the reaction stands for,
+// returning whether the message is a poll collecting votes with reactions
+func (s *Slacker) handlePollReactionChange(channel string, timestamp string, userID string, reaction string, change func(channel string, timestamp string, userID string, option int)) bool {
+	poll, err := s.loadPoll(channel, timestamp)
+	if err != nil {
+		s.reportError(err)
+		return false
+	}
+
+	if poll == nil || !poll.Reactions {
+		return false
+	}
+
+	if userID == s.botUserID() {
+		return true
+	}
+
+	for i := range poll.Options {
+		if numberEmojis[i] == reaction {
+			change(channel, timestamp, userID, i)
+			break
+		}
+	}
+	return true
+}
+
+// vote records the user's vote and updates the tally in the poll's message
+func (s *Slacker) vote(channel string, timestamp string, userID string, option int) {
+	s.updatePoll(channel, timestamp, func(poll *Poll) bool {
+		if option < 0 || option >= len(poll.Options) {
+			return false
+		}
+
+		previous, ok := poll.Votes[userID]
+		if ok && previous == option {
+			return false
+		}
+		poll.Votes[userID] = option
+		return true
+	})
+}
+
+// unvote withdraws the user's vote for the option, if it is still their vote, and updates the tally in the poll's message
+func (s *Slacker) unvote(channel string, timestamp string, userID string, option int) {
+	s.updatePoll(channel, timestamp, func(poll *Poll) bool {
+		previous, ok := poll.Votes[userID]
+		if !ok || previous != option {
+			return false
+		}
+		delete(poll.Votes, userID)
+		return true
+	})
+}
+
+// updatePoll applies the change to the poll, saving it and updating its message when it returns true
+func (s *Slacker) updatePoll(channel string, timestamp string, change func(poll *Poll) bool) {
+	s.pollsMutex.Lock()
+	defer s.pollsMutex.Unlock()
+
+	poll, err := s.loadPoll(channel, timestamp)
+	if err != nil {
+		s.reportError(err)
+		return
+	}
+
+	if poll == nil || !change(poll) {
+		return
+	}
+
+	err = s.savePoll(channel, timestamp, poll)
+	if err != nil {
+		s.reportError(err)
+		return
+	}
+
+	text, blocks := poll.render()
+	err = s.UpdateBlocks(channel, timestamp, text, blocks...)
+	if err != nil {
+		s.reportError(err)
+	}
+}
+
+func (s *Slacker) loadPoll(channel string, timestamp string) (*Poll, error) {
+	data, ok, err := s.sessionStore().Get(pollKey(channel, timestamp))
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	poll := &Poll{}
+	err = json.Unmarshal(data, poll)
+	if err != nil {
+		return nil, err
+	}
+
+	if poll.Votes == nil {
+		poll.Votes = make(map[string]int)
+	}
+	return poll, nil
+}
+
+func (s *Slacker) savePoll(channel string, timestamp string, poll *Poll) error {
+	data, err := json.Marshal(poll)
+	if err != nil {
+		return err
+	}
+	return s.sessionStore().Set(pollKey(channel, timestamp), data, pollTTL)
+}
+
+// render returns the text and blocks of the poll along with its current tally
+func (p *Poll) render() (string, []slack.Block) {
+	blocks := []slack.Block{markdownSection(format.Bold(p.Question))}
+
+	tally := p.Tally()
+	for i, option := range p.Options {
+		if p.Reactions {
+			blocks = append(blocks, markdownSection(fmt.Sprintf(pollReactionFormat, numberEmojis[i], option, tally[i])))
+			continue
+		}
+
+		block := markdownSection(fmt.Sprintf(pollOptionFormat, option, tally[i]))
+		block.Accessory = slack.NewAccessory(slack.NewButtonBlockElement(pollVoteAction, strconv.Itoa(i), plainTextObject(pollVoteText)))
+		blocks = append(blocks, block)
+	}
+
+	blocks = append(blocks, markdownContext(fmt.Sprintf(pollTotalFormat, len(p.Votes))))
+	return p.Question, blocks
+}
+
+// pollKey returns the key of the state of the poll posted as the message
+func pollKey(channel string, timestamp string) string {
+	return fmt.Sprintf(pollKeyFormat, channel, timestamp)
+}
@@ -0,0 +1,156 @@
+package slacker
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	presenceChangeEventType = "presence_change"
+	userChangeEventType     = "user_change"
+	usersGetPresenceMethod  = "users.getPresence"
+	userField               = "user"
+	activePresence          = "active"
+)
+
+// PresenceChangeEvent is received when a subscribed user comes online or goes away
+type PresenceChangeEvent struct {
+	UserID   string
+	Presence string
+	Previous string
+}
+
+// Online determines whether the user is now active
+func (e *PresenceChangeEvent) Online() bool {
+	return e.Presence == activePresence
+}
+
+// userChangeEvent is the user_change event delivered by the Events API
+type userChangeEvent struct {
+	User *slack.User `json:"user"`
+}
+
+// userPresenceResponse is the users.getPresence response
+type userPresenceResponse struct {
+	Presence string `json:"presence"`
+}
+
+// OnPresenceChange handle subscribed users coming online or going away.
+// Presence changes are only delivered over the RTM connection
+func (s *Slacker) OnPresenceChange(presenceChangeHandler func(event *PresenceChangeEvent)) {
+	s.presenceChangeHandler = presenceChangeHandler
+}
+
+// OnUserChange handle users of the workspace changing their profile, status or settings.
+// The users cached by UserInfo, UserByEmail and UserByName are updated as well
+func (s *Slacker) OnUserChange(userChangeHandler func(user *slack.User)) {
+	s.userChangeHandler = userChangeHandler
+}
+
+// SubscribePresence starts delivering the presence changes of the users to the OnPresenceChange handler.
+// Their current presence is looked up, so only actual changes are delivered.
+// Slack is asked for the changes of all the subscribed users now and whenever the RTM connection is established
+func (s *Slacker) SubscribePresence(userIDs ...string) error {
+	presences := make(map[string]string, len(userIDs))
+	for _, userID := range userIDs {
+		response := &userPresenceResponse{}
+		err := s.queryAPI(usersGetPresenceMethod, url.Values{userField: {userID}}, response)
+		if err != nil {
+			return err
+		}
+		presences[userID] = response.Presence
+	}
+
+	s.presenceMutex.Lock()
+	for userID, presence := range presences {
+		s.presences[userID] = presence
+	}
+	s.presenceMutex.Unlock()
+
+	s.sendPresenceSubscriptions()
+	return nil
+}
+
+// UnsubscribePresence stops delivering the presence changes of the users
+func (s *Slacker) UnsubscribePresence(userIDs ...string) {
+	s.presenceMutex.Lock()
+	for _, userID := range userIDs {
+		delete(s.presences, userID)
+	}
+	s.presenceMutex.Unlock()
+
+	s.sendPresenceSubscriptions()
+}
+
+// sendPresenceSubscriptions asks Slack for the presence changes of the subscribed users over the RTM connection,
+// replacing the users it was asked for before. Nothing is sent while the connection is not established
+func (s *Slacker) sendPresenceSubscriptions() {
+	if !s.isConnected() || s.RTM.GetInfo() == nil {
+		return
+	}
+	s.RTM.SendMessage(s.RTM.NewSubscribeUserPresence(s.PresenceSubscriptions()))
+}
+
+// PresenceSubscriptions returns the users whose presence changes are delivered
+func (s *Slacker) PresenceSubscriptions() []string {
+	s.presenceMutex.Lock()
+	defer s.presenceMutex.Unlock()
+
+	userIDs := make([]string, 0, len(s.presences))
+	for userID := range s.presences {
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs
+}
+
+// handlePresenceChange delivers the change if the user is subscribed and their presence did change,
+// returning whether it was delivered
+func (s *Slacker) handlePresenceChange(event *slack.PresenceChangeEvent) bool {
+	s.presenceMutex.Lock()
+	previous, ok := s.presences[event.User]
+	if ok {
+		s.presences[event.User] = event.Presence
+	}
+	s.presenceMutex.Unlock()
+
+	if !ok || previous == event.Presence || s.presenceChangeHandler == nil {
+		return false
+	}
+
+	s.presenceChangeHandler(&PresenceChangeEvent{UserID: event.User, Presence: event.Presence, Previous: previous})
+	return true
+}
+
+func (s *Slacker) handleUserChangeData(data json.RawMessage) {
+	event := &userChangeEvent{}
+	err := json.Unmarshal(data, event)
+	if err != nil {
+		s.reportError(err)
+		return
+	}
+
+	if event.User == nil {
+		return
+	}
+	s.handleUserChange(event.User)
+}
+
+// handleUserChange refreshes the cached user before delivering the change
+func (s *Slacker) handleUserChange(user *slack.User) {
+	if len(user.Profile.Email) > 0 {
+		s.users.Set(emailKeyPrefix+strings.ToLower(user.Profile.Email), user)
+	}
+	if len(user.Profile.DisplayName) > 0 {
+		s.users.Set(nameKeyPrefix+strings.ToLower(user.Profile.DisplayName), user)
+	}
+	s.users.Set(nameKeyPrefix+strings.ToLower(user.Name), user)
+	s.users.Set(idKeyPrefix+user.ID, user)
+
+	if s.userChangeHandler == nil {
+		return
+	}
+	s.userChangeHandler(user)
+}
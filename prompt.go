@@ -0,0 +1,203 @@
+package slacker
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shomali11/slacker/format"
+	"github.com/slack-go/slack"
+)
+
+const (
+	defaultPromptTimeout = 2 * time.Hour
+	promptMissingFormat  = "%s did not answer"
+	promptKeyFormat      = "%s:%s:%s"
+)
+
+// Prompt asks a set of users questions in direct messages and posts a summary of their answers to a channel,
+// as standup bots do. Users answer the questions one after the other by replying to the bot
+type Prompt struct {
+	// Title heads the summary
+	Title string
+
+	// Users are the IDs of the users asked
+	Users []string
+
+	// Questions are asked in order, each once the previous one is answered
+	Questions []string
+
+	// Channel is where the summary is posted
+	Channel string
+
+	// Timeout is how long users have to answer before the summary is posted anyway, defaults to two hours
+	Timeout time.Duration
+}
+
+// promptRun collects the answers of one run of a prompt
+type promptRun struct {
+	prompt   *Prompt
+	mutex    sync.Mutex
+	answers  map[string][]string
+	pending  int
+	finished chan struct{}
+}
+
+// promptCollection is a user's progress through a run of a prompt, answered in a conversation
+type promptCollection struct {
+	run *promptRun
+}
+
+// promptKey returns the key of the prompt the user answers in the channel, and in the thread if any
+func promptKey(userID string, channel string, threadTimestamp string) string {
+	return fmt.Sprintf(promptKeyFormat, userID, channel, threadTimestamp)
+}
+
+// SchedulePrompt runs the prompt at every time of the schedule until the bot is closed
+func (s *Slacker) SchedulePrompt(schedule Schedule, prompt *Prompt) {
+	s.runScheduled(schedule, func() {
+		err := s.RunPrompt(prompt)
+		if err != nil {
+			s.reportError(err)
+		}
+	})
+}
+
+// RunPrompt asks the users the prompt's first question right away, then waits for their answers
+// and posts the summary once everyone answered or the timeout passed
+func (s *Slacker) RunPrompt(prompt *Prompt) error {
+	if len(prompt.Questions) == 0 {
+		return nil
+	}
+
+	channels := make(map[string]string)
+	for _, userID := range prompt.Users {
+		channel, err := s.directChannel(userID)
+		if err != nil {
+			s.reportError(err)
+			continue
+		}
+		channels[userID] = channel
+	}
+
+	run := &promptRun{prompt: prompt, answers: make(map[string][]string), pending: len(channels), finished: make(chan struct{})}
+	if run.pending == 0 {
+		close(run.finished)
+	}
+
+	s.promptsMutex.Lock()
+	for userID, channel := range channels {
+		s.prompts[promptKey(userID, channel, empty)] = &promptCollection{run: run}
+	}
+	s.promptsMutex.Unlock()
+
+	for _, channel := range channels {
+		s.postMessage(channel, prompt.Questions[0])
+	}
+
+	timeout := prompt.Timeout
+	if timeout == 0 {
+		timeout = defaultPromptTimeout
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-run.finished:
+	case <-timer.C:
+	case <-s.done:
+		return nil
+	}
+
+	s.promptsMutex.Lock()
+	for key, collection := range s.prompts {
+		if collection.run == run {
+			delete(s.prompts, key)
+		}
+	}
+	s.promptsMutex.Unlock()
+
+	return s.postMessage(prompt.Channel, run.summary())
+}
+
+// collectAnswer records the message as the answer to the question the user was last asked in the conversation,
+// asking the next one. It returns whether the message was an answer, in which case it is not matched against the commands
+func (s *Slacker) collectAnswer(event *slack.MessageEvent) bool {
+	key := promptKey(event.User, event.Channel, event.ThreadTimestamp)
+	s.promptsMutex.Lock()
+	collection, ok := s.prompts[key]
+	s.promptsMutex.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	run := collection.run
+	questions := run.prompt.Questions
+	run.mutex.Lock()
+	if len(run.answers[event.User]) >= len(questions) {
+		s.forgetCollection(key, collection)
+		run.mutex.Unlock()
+		return false
+	}
+
+	answers := append(run.answers[event.User], event.Text)
+	run.answers[event.User] = answers
+
+	done := len(answers) >= len(questions)
+	if done {
+		run.pending--
+		if run.pending == 0 {
+			close(run.finished)
+		}
+		s.forgetCollection(key, collection)
+	}
+	run.mutex.Unlock()
+
+	if done {
+		return true
+	}
+
+	s.postMessage(event.Channel, questions[len(answers)])
+	return true
+}
+
+// forgetCollection stops collecting the answers of the conversation with the key, unless another run of the prompt took it over
+func (s *Slacker) forgetCollection(key string, collection *promptCollection) {
+	s.promptsMutex.Lock()
+	defer s.promptsMutex.Unlock()
+
+	if s.prompts[key] == collection {
+		delete(s.prompts, key)
+	}
+}
+
+// summary returns the answers of every user to every question
+func (r *promptRun) summary() string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var summary strings.Builder
+	if len(r.prompt.Title) > 0 {
+		summary.WriteString(format.Bold(r.prompt.Title) + newLine)
+	}
+
+	for _, userID := range r.prompt.Users {
+		answers, ok := r.answers[userID]
+		if !ok {
+			summary.WriteString(format.Italic(fmt.Sprintf(promptMissingFormat, format.Mention(userID))) + newLine)
+			continue
+		}
+
+		summary.WriteString(format.Bold(format.Mention(userID)) + newLine)
+		for i, answer := range answers {
+			if i >= len(r.prompt.Questions) {
+				break
+			}
+			summary.WriteString(format.Italic(r.prompt.Questions[i]) + newLine + answer + newLine)
+		}
+	}
+	return summary.String()
+}
@@ -0,0 +1,64 @@
+package slacker
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePoster counts the messages posted without calling the Web API
+type fakePoster struct {
+	posted int
+}
+
+func (p *fakePoster) PostMessage(channel string, options ...slack.MsgOption) (string, string, error) {
+	p.posted++
+	return channel, "1234.5678", nil
+}
+
+func (p *fakePoster) PostEphemeral(channel string, userID string, options ...slack.MsgOption) (string, error) {
+	return "1234.5678", nil
+}
+
+func TestCollectAnswer(t *testing.T) {
+	tests := []struct {
+		name      string
+		questions []string
+		messages  []string
+		collected []bool
+		answers   []string
+		posted    int
+	}{
+		{name: "single question", questions: []string{"Done?"}, messages: []string{"yes"}, collected: []bool{true}, answers: []string{"yes"}},
+		{name: "next question", questions: []string{"Done?", "Blocked?"}, messages: []string{"yes", "no"}, collected: []bool{true, true}, answers: []string{"yes", "no"}, posted: 1},
+		{name: "overflow", questions: []string{"Done?"}, messages: []string{"yes", "again", "more"}, collected: []bool{true, false, false}, answers: []string{"yes"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			poster := &fakePoster{}
+			bot := NewClient("xoxb-test", WithMessagePoster(poster))
+
+			prompt := &Prompt{Questions: test.questions, Users: []string{"U1"}}
+			run := &promptRun{prompt: prompt, answers: make(map[string][]string), pending: 1, finished: make(chan struct{})}
+			key := promptKey("U1", "D1", empty)
+
+			for i, message := range test.messages {
+				// Messages racing the last answer may still find the conversation
+				bot.prompts[key] = &promptCollection{run: run}
+
+				event := &slack.MessageEvent{Msg: slack.Msg{User: "U1", Channel: "D1", Text: message}}
+				assert.Equal(t, test.collected[i], bot.collectAnswer(event), message)
+			}
+
+			assert.Equal(t, test.answers, run.answers["U1"])
+			assert.Equal(t, 0, run.pending)
+			assert.Equal(t, test.posted, poster.posted)
+			assert.NotPanics(t, func() { run.summary() })
+
+			_, ok := bot.prompts[key]
+			assert.False(t, ok)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package slacker
+
+import (
+	"encoding/json"
+	"sync/atomic"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	outboxKey          = "slacker:outbox"
+	queueFlushInterval = 30 * time.Second
+)
+
+// queuedMessage is a message waiting to be delivered
+type queuedMessage struct {
+	Channel string `json:"channel"`
+	Text    string `json:"text"`
+}
+
+// queueingSender sends messages over the connection, queueing them in the store while disconnected
+type queueingSender struct {
+	slacker *Slacker
+}
+
+// SendMessage sends the message, or queues it while disconnected
+func (q *queueingSender) SendMessage(message *slack.OutgoingMessage) {
+	s := q.slacker
+	if s.isConnected() || s.defaults.Store == nil {
+		s.sender.SendMessage(message)
+		return
+	}
+
+	// Typing indicators are meaningless once reconnected
+	if message.Type != messageEventType {
+		return
+	}
+
+	err := s.enqueueMessage(message.Channel, message.Text)
+	if err != nil {
+		s.reportError(err)
+	}
+}
+
+// NewOutgoingMessage prepares a message to send
+func (q *queueingSender) NewOutgoingMessage(text string, channelID string, options ...slack.RTMsgOption) *slack.OutgoingMessage {
+	return q.slacker.sender.NewOutgoingMessage(text, channelID, options...)
+}
+
+// NewTypingMessage prepares a typing indicator to send
+func (q *queueingSender) NewTypingMessage(channelID string) *slack.OutgoingMessage {
+	return q.slacker.sender.NewTypingMessage(channelID)
+}
+
+func (s *Slacker) isConnected() bool {
+	return atomic.LoadInt32(&s.connected) == 1
+}
+
+func (s *Slacker) setConnected(connected bool) {
+	var value int32
+	if connected {
+		value = 1
+	}
+	atomic.StoreInt32(&s.connected, value)
+}
+
+// enqueueMessage adds the message to the queue persisted in the store
+func (s *Slacker) enqueueMessage(channel string, text string) error {
+	s.queueMutex.Lock()
+	defer s.queueMutex.Unlock()
+
+	messages, err := s.loadQueue()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(append(messages, &queuedMessage{Channel: channel, Text: text}))
+	if err != nil {
+		return err
+	}
+	return s.defaults.Store.Set(outboxKey, data, 0)
+}
+
+// flushQueuePeriodically flushes the queue until the bot is closed, so messages queued by bots
+// receiving events over HTTP, which never reconnect, are delivered as well
+func (s *Slacker) flushQueuePeriodically() {
+	if s.defaults.Store == nil {
+		return
+	}
+
+	ticker := time.NewTicker(queueFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.flushQueue()
+		}
+	}
+}
+
+// flushQueue delivers the queued messages through the Web API, messages that fail again are queued again
+func (s *Slacker) flushQueue() {
+	if s.defaults.Store == nil {
+		return
+	}
+
+	s.queueMutex.Lock()
+	messages, err := s.loadQueue()
+	if err == nil && len(messages) > 0 {
+		err = s.defaults.Store.Delete(outboxKey)
+	}
+	s.queueMutex.Unlock()
+
+	if err != nil {
+		s.reportError(err)
+		return
+	}
+
+	for _, message := range messages {
+		s.postMessage(message.Channel, message.Text)
+	}
+}
+
+func (s *Slacker) loadQueue() ([]*queuedMessage, error) {
+	data, ok, err := s.defaults.Store.Get(outboxKey)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	var messages []*queuedMessage
+	err = json.Unmarshal(data, &messages)
+	return messages, err
+}
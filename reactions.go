@@ -0,0 +1,142 @@
+package slacker
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	reactionAddedEventType   = "reaction_added"
+	reactionRemovedEventType = "reaction_removed"
+	reactionMenuOptionFmt    = "%s :%s: %s"
+	reactionMenuTTL          = 24 * time.Hour
+	tooManyMenuOptionsError  = "a reaction menu supports at most %d options"
+)
+
+// numberEmojis are the reactions used to select the options of a reaction menu, in order
+var numberEmojis = []string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "keycap_ten"}
+
+// ReactionSelection is an option a user picked by reacting to a reaction menu
+type ReactionSelection struct {
+	UserID string
+	Index  int
+	Option string
+}
+
+// reactionMenu is a posted reaction menu waiting for selections
+type reactionMenu struct {
+	options  []string
+	callback func(selection *ReactionSelection)
+	expiry   time.Time
+}
+
+// ReactionMenu posts the prompt followed by the numbered options to the channel with the ID and adds a number reaction
+// for each option. The callback is called whenever someone other than the bot reacts with one of them.
+// Menus are kept in memory for a day and do not survive restarts. This works without interactivity enabled
+func (s *Slacker) ReactionMenu(channel string, prompt string, options []string, callback func(selection *ReactionSelection)) error {
+	if len(options) > len(numberEmojis) {
+		return fmt.Errorf(tooManyMenuOptionsError, len(numberEmojis))
+	}
+
+	text := prompt
+	for i, option := range options {
+		text += fmt.Sprintf(reactionMenuOptionFmt, newLine, numberEmojis[i], option)
+	}
+
+	timestamp, err := s.postWithRetries(channel, text)
+	if err != nil {
+		return err
+	}
+
+	s.addReactionMenu(channel, timestamp, &reactionMenu{options: options, callback: callback})
+
+	for i := range options {
+		err := s.addReaction(numberEmojis[i], channel, timestamp)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Slacker) addReactionMenu(channel string, timestamp string, menu *reactionMenu) {
+	s.reactionMenusMutex.Lock()
+	defer s.reactionMenusMutex.Unlock()
+
+	now := time.Now()
+	for key, menu := range s.reactionMenus {
+		if now.After(menu.expiry) {
+			delete(s.reactionMenus, key)
+		}
+	}
+
+	menu.expiry = now.Add(reactionMenuTTL)
+	s.reactionMenus[fmt.Sprintf(messageKeyFormat, channel, timestamp)] = menu
+}
+
+func (s *Slacker) reactionMenu(channel string, timestamp string) *reactionMenu {
+	s.reactionMenusMutex.Lock()
+	defer s.reactionMenusMutex.Unlock()
+
+	menu, ok := s.reactionMenus[fmt.Sprintf(messageKeyFormat, channel, timestamp)]
+	if !ok || time.Now().After(menu.expiry) {
+		return nil
+	}
+	return menu
+}
+
+// handleReactionAddedData decodes a reaction received through the Events API.
+// Reactions the bot does not handle itself are passed on to the default event handler
+func (s *Slacker) handleReactionAddedData(data json.RawMessage) {
+	event := &slack.ReactionAddedEvent{}
+	err := json.Unmarshal(data, event)
+	if err != nil {
+		s.reportError(err)
+		return
+	}
+
+	if s.handleReactionAdded(event) || s.defaultEventHandler == nil {
+		return
+	}
+	s.defaultEventHandler(data)
+}
+
+// handleReactionRemovedData decodes a reaction removal received through the Events API.
+// Removals the bot does not handle itself are passed on to the default event handler
+func (s *Slacker) handleReactionRemovedData(data json.RawMessage) {
+	event := &slack.ReactionRemovedEvent{}
+	err := json.Unmarshal(data, event)
+	if err != nil {
+		s.reportError(err)
+		return
+	}
+
+	if s.handlePollReactionRemoved(event) || s.defaultEventHandler == nil {
+		return
+	}
+	s.defaultEventHandler(data)
+}
+
+// handleReactionAdded returns whether the reaction was handled by the bot
+func (s *Slacker) handleReactionAdded(event *slack.ReactionAddedEvent) bool {
+	menu := s.reactionMenu(event.Item.Channel, event.Item.Timestamp)
+	if menu == nil {
+		return s.handlePollReaction(event) || s.handleBotMessageReaction(event)
+	}
+
+	// The bot's own reactions are how the options are offered
+	if event.User == s.botUserID() {
+		return true
+	}
+
+	for i := range menu.options {
+		if numberEmojis[i] == event.Reaction {
+			menu.callback(&ReactionSelection{UserID: event.User, Index: i, Option: menu.options[i]})
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package slacker
+
+import (
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	rtmConnectMethod             = "rtm.connect"
+	defaultReconnectInitialDelay = 100 * time.Millisecond
+	defaultReconnectMaxDelay     = 5 * time.Minute
+)
+
+// newRTM creates the Real-Time Messaging connection, whose attempts to connect again are delayed
+// as set by the reconnect options
+func newRTM(token string, slacker *Slacker) *slack.RTM {
+	httpClient := *slacker.defaults.HTTPClient
+	transport := httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	httpClient.Transport = &reconnectDelayer{transport: transport, slacker: slacker}
+
+	client := slack.New(token, slack.OptionHTTPClient(&httpClient))
+	return client.NewRTM(slack.RTMOptionDialer(newWebsocketDialer(slacker.defaults.HTTPClient)))
+}
+
+// reconnectDelayer delays the requests opening the connection again, on top of the delay of the slack package
+type reconnectDelayer struct {
+	transport http.RoundTripper
+	slacker   *Slacker
+}
+
+// RoundTrip waits for the pending reconnect delay, if any, before opening the connection
+func (d *reconnectDelayer) RoundTrip(request *http.Request) (*http.Response, error) {
+	if !strings.HasSuffix(request.URL.Path, rtmConnectMethod) {
+		return d.transport.RoundTrip(request)
+	}
+
+	delay, _ := d.slacker.reconnectDelay.Load().(time.Duration)
+	if delay > 0 {
+		d.slacker.reconnectDelay.Store(time.Duration(0))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-request.Context().Done():
+			timer.Stop()
+			return nil, request.Context().Err()
+		case <-d.slacker.done:
+			timer.Stop()
+			return nil, ErrDisconnected
+		}
+	}
+	return d.transport.RoundTrip(request)
+}
+
+// delayReconnect sets the delay of the next attempt to connect, after the given number of failed ones.
+// The slack package already waits for the event's backoff, so only the remainder is added
+func (s *Slacker) delayReconnect(event *slack.ConnectionErrorEvent, attempts int) {
+	delay := s.defaults.reconnectDelay(attempts) - event.Backoff
+	if delay < 0 {
+		delay = 0
+	}
+	s.reconnectDelay.Store(delay)
+}
+
+// reconnectDelay returns the delay before the attempt following the failed ones, doubling from the initial delay
+// up to the maximum, with a random jitter added
+func (d *ClientDefaults) reconnectDelay(attempts int) time.Duration {
+	delay := d.ReconnectMaxDelay
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	// Beyond 32 doublings, any initial delay exceeds any sensible maximum
+	if attempts <= 32 {
+		doubled := d.ReconnectInitialDelay << uint(attempts-1)
+		if doubled > 0 && doubled < delay {
+			delay = doubled
+		}
+	}
+
+	if d.ReconnectJitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(d.ReconnectJitter)))
+	}
+	return delay
+}
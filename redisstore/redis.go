@@ -0,0 +1,238 @@
+// Package redisstore provides a slacker.Store backed by Redis, so state survives restarts and is shared across replicas
+package redisstore
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shomali11/slacker"
+)
+
+const (
+	tcpNetwork         = "tcp"
+	lineEnding         = "\r\n"
+	arrayFormat        = "*%d\r\n"
+	bulkStringFormat   = "$%d\r\n%s\r\n"
+	simpleStringMarker = '+'
+	errorMarker        = '-'
+	integerMarker      = ':'
+	bulkStringMarker   = '$'
+	authCommand        = "AUTH"
+	selectCommand      = "SELECT"
+	getCommand         = "GET"
+	setCommand         = "SET"
+	delCommand         = "DEL"
+	expiryOption       = "PX"
+	defaultAddress     = "localhost:6379"
+	defaultTimeout     = 5 * time.Second
+	unexpectedReply    = "unexpected reply from redis: %q"
+	redisErrorPrefix   = "redis: "
+	defaultPrefix      = "slacker:"
+)
+
+var _ slacker.Store = (*Store)(nil)
+
+// Options configures the connection to Redis
+type Options struct {
+	// Address is the host and port of the server, defaults to localhost:6379
+	Address string
+
+	// Password is used to authenticate, if set
+	Password string
+
+	// DB is the database selected after connecting
+	DB int
+
+	// Prefix is prepended to every key, defaults to "slacker:"
+	Prefix string
+
+	// Timeout limits connecting and every command, defaults to 5 seconds
+	Timeout time.Duration
+}
+
+// New creates a store connecting to Redis with the options. The connection is made on first use
+func New(options Options) *Store {
+	if len(options.Address) == 0 {
+		options.Address = defaultAddress
+	}
+
+	if len(options.Prefix) == 0 {
+		options.Prefix = defaultPrefix
+	}
+
+	if options.Timeout == 0 {
+		options.Timeout = defaultTimeout
+	}
+	return &Store{options: options}
+}
+
+// Store keeps values in Redis under prefixed keys, with Redis expiring them
+type Store struct {
+	options Options
+	mutex   sync.Mutex
+	conn    net.Conn
+	reader  *bufio.Reader
+}
+
+// Get returns the value stored under the key and whether it was found
+func (s *Store) Get(key string) ([]byte, bool, error) {
+	reply, err := s.do(getCommand, s.options.Prefix+key)
+	if err != nil || reply == nil {
+		return nil, false, err
+	}
+
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf(unexpectedReply, reply)
+	}
+	return value, true, nil
+}
+
+// Set stores the value under the key, expiring it after the TTL unless the TTL is zero
+func (s *Store) Set(key string, value []byte, ttl time.Duration) error {
+	args := []string{setCommand, s.options.Prefix + key, string(value)}
+	if ttl > 0 {
+		args = append(args, expiryOption, strconv.FormatInt(int64(ttl/time.Millisecond), 10))
+	}
+
+	_, err := s.do(args...)
+	return err
+}
+
+// Delete removes the key
+func (s *Store) Delete(key string) error {
+	_, err := s.do(delCommand, s.options.Prefix+key)
+	return err
+}
+
+// Close closes the connection to Redis
+func (s *Store) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// do sends the command and reads its reply, reconnecting if the connection was lost
+func (s *Store) do(args ...string) (interface{}, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.conn == nil {
+		err := s.connect()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reply, err := s.send(args...)
+	if _, ok := err.(redisError); err != nil && !ok {
+		s.conn.Close()
+		s.conn = nil
+	}
+	return reply, err
+}
+
+func (s *Store) connect() error {
+	conn, err := net.DialTimeout(tcpNetwork, s.options.Address, s.options.Timeout)
+	if err != nil {
+		return err
+	}
+
+	s.conn = conn
+	s.reader = bufio.NewReader(conn)
+
+	if len(s.options.Password) > 0 {
+		_, err = s.send(authCommand, s.options.Password)
+	}
+
+	if err == nil && s.options.DB != 0 {
+		_, err = s.send(selectCommand, strconv.Itoa(s.options.DB))
+	}
+
+	if err != nil {
+		conn.Close()
+		s.conn = nil
+	}
+	return err
+}
+
+func (s *Store) send(args ...string) (interface{}, error) {
+	err := s.conn.SetDeadline(time.Now().Add(s.options.Timeout))
+	if err != nil {
+		return nil, err
+	}
+
+	command := fmt.Sprintf(arrayFormat, len(args))
+	for _, arg := range args {
+		command += fmt.Sprintf(bulkStringFormat, len(arg), arg)
+	}
+
+	_, err = io.WriteString(s.conn, command)
+	if err != nil {
+		return nil, err
+	}
+	return s.readReply()
+}
+
+func (s *Store) readReply() (interface{}, error) {
+	line, err := s.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	line = strings.TrimSuffix(line, lineEnding)
+	if len(line) == 0 {
+		return nil, fmt.Errorf(unexpectedReply, line)
+	}
+
+	switch line[0] {
+	case simpleStringMarker:
+		return line[1:], nil
+
+	case errorMarker:
+		return nil, redisError(line[1:])
+
+	case integerMarker:
+		return strconv.ParseInt(line[1:], 10, 64)
+
+	case bulkStringMarker:
+		length, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		if length < 0 {
+			return nil, nil
+		}
+
+		value := make([]byte, length+len(lineEnding))
+		_, err = io.ReadFull(s.reader, value)
+		if err != nil {
+			return nil, err
+		}
+		return value[:length], nil
+
+	default:
+		return nil, fmt.Errorf(unexpectedReply, line)
+	}
+}
+
+// redisError is an error replied by Redis, which leaves the connection usable
+type redisError string
+
+func (e redisError) Error() string {
+	return redisErrorPrefix + string(e)
+}
@@ -2,9 +2,10 @@ package slacker
 
 import (
 	"context"
+	"time"
 
-	"github.com/nlopes/slack"
 	"github.com/shomali11/proper"
+	"github.com/slack-go/slack"
 )
 
 const (
@@ -16,11 +17,89 @@ func NewRequest(ctx context.Context, event *slack.MessageEvent, properties *prop
 	return &Request{Context: ctx, Event: event, properties: properties}
 }
 
-// Request contains the Event received and parameters
+// Request contains the Event received and parameters, along with the Web API client for custom API calls.
+// Handlers work the same whatever the transport the message arrived through, which is available
+// along with its specific details for handlers that need them
 type Request struct {
-	Context    context.Context
-	Event      *slack.MessageEvent
-	properties *proper.Properties
+	Context      context.Context
+	Event        *slack.MessageEvent
+	Client       *slack.Client
+	Transport    Transport
+	SlashCommand *SlashCommand
+	properties   *proper.Properties
+	store        Store
+	sessionTTL   time.Duration
+	session      *Session
+	slacker      *Slacker
+	files        []*slack.File
+	enterpriseID string
+	userTeamID   string
+}
+
+// Text returns the text of the message
+func (r *Request) Text() string {
+	return r.Event.Text
+}
+
+// UserID returns the ID of the user who sent the message
+func (r *Request) UserID() string {
+	return r.Event.User
+}
+
+// ChannelID returns the ID of the channel the message was sent in
+func (r *Request) ChannelID() string {
+	return r.Event.Channel
+}
+
+// TeamID returns the ID of the workspace the message was sent in
+func (r *Request) TeamID() string {
+	return r.Event.Team
+}
+
+// Timestamp returns the timestamp of the message, which also identifies it within the channel
+func (r *Request) Timestamp() string {
+	return r.Event.Timestamp
+}
+
+// Session returns the session of the user in the channel, loading it from the store the first time.
+// If it could not be loaded, an empty session is returned along with the error
+func (r *Request) Session() (*Session, error) {
+	if r.session != nil {
+		return r.session, nil
+	}
+
+	if r.store == nil {
+		r.store = defaultStore
+	}
+
+	session, err := loadSession(r.store, sessionKey(r.Event.Team, r.Event.Channel, r.Event.User), r.sessionTTL)
+	if err != nil {
+		return session, err
+	}
+
+	r.session = session
+	return session, nil
+}
+
+// History returns the previous messages the user sent the bot in the channel, oldest first.
+// The message being handled is not included
+func (r *Request) History() ([]*HistoryMessage, error) {
+	if r.store == nil {
+		return nil, nil
+	}
+
+	history, err := loadHistory(r.store, historyKey(r.Event.Team, r.Event.Channel, r.Event.User))
+	if err != nil {
+		return nil, err
+	}
+
+	previous := make([]*HistoryMessage, 0, len(history))
+	for _, message := range history {
+		if message.Timestamp != r.Event.Timestamp {
+			previous = append(previous, message)
+		}
+	}
+	return previous, nil
 }
 
 // Param attempts to look up a string value by key. If not found, return the an empty string
@@ -0,0 +1,58 @@
+package slacker
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	requestIDBytes       = 8
+	requestIDErrorFormat = "%s (request ID %s)"
+)
+
+// requestIDKey is the context key of the ID of the message being handled
+type requestIDKey struct{}
+
+// newRequestID generates a random ID identifying the handling of a message
+func newRequestID() string {
+	id := make([]byte, requestIDBytes)
+	_, err := rand.Read(id)
+	if err != nil {
+		return empty
+	}
+	return hex.EncodeToString(id)
+}
+
+// withRequestID returns a context carrying the request ID
+func withRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFrom returns the ID of the message being handled carried by the context, or empty if there is none
+func RequestIDFrom(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// ID returns the unique ID generated when the message started being handled, to correlate logs and error reports
+func (r *Request) ID() string {
+	if r.Context == nil {
+		return empty
+	}
+	return RequestIDFrom(r.Context)
+}
+
+// reportRequestError reports the error along with the ID of the request carried by the context, if any
+func (s *Slacker) reportRequestError(ctx context.Context, err error) {
+	s.reportError(withRequestIDError(err, RequestIDFrom(ctx)))
+}
+
+// withRequestIDError appends the request ID to the error, so users can quote it when reporting issues
+func withRequestIDError(err error, requestID string) error {
+	if len(requestID) == 0 {
+		return err
+	}
+	return fmt.Errorf(requestIDErrorFormat, err.Error(), requestID)
+}
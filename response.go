@@ -2,43 +2,296 @@ package slacker
 
 import (
 	"fmt"
+	"io"
+	"time"
 
-	"github.com/nlopes/slack"
+	"github.com/shomali11/slacker/format"
+	"github.com/slack-go/slack"
 )
 
 const (
 	errorFormat = "*Error:* _%s_"
 )
 
-// A ResponseWriter interface is used to respond to an event
+// A ResponseWriter interface is used to respond to an event.
+// All replies made by the framework, including the help, go through it, so custom implementations
+// can be provided with CustomResponse to record replies in tests or send them to several destinations
 type ResponseWriter interface {
-	Reply(text string)
+	// Reply sends a message to the channel the event came from
+	Reply(text string, options ...ReplyOption)
+
+	// ReportError sends a formatted error message to the channel the event came from
 	ReportError(err error)
+
+	// Typing sends a typing indicator to the channel the event came from
 	Typing()
 }
 
+// A RichResponseWriter is a ResponseWriter that can also reply with temporary messages, tables and images.
+// The ResponseWriters created by the framework implement it, custom ones may not, so handlers detect it
+// with a type assertion and fall back to Reply otherwise
+type RichResponseWriter interface {
+	ResponseWriter
+
+	// ReplyTemporary sends a message to the channel the event came from and deletes it once the TTL elapses.
+	// It fails with ErrCannotDelete, without sending anything, when the message could not be deleted
+	ReplyTemporary(text string, ttl time.Duration, options ...ReplyOption) error
+
+	// ReplyTable sends the rows under the headers to the channel the event came from, in a code block
+	// when the table fits in a message, or else as a text snippet or a CSV file depending on its size
+	ReplyTable(headers []string, rows [][]string, options ...ReplyOption) error
+
+	// ReplyImage uploads a PNG image, such as a rendered graph, and shares it with the caption
+	// in the channel the event came from. It fails with ErrCannotShareImage when the image could not be shared
+	ReplyImage(name string, png io.Reader, caption string, options ...ReplyOption) error
+
+	// Permalink returns a link to the message with the timestamp in the channel the event came from,
+	// such as the message being handled, to reference it or cross-post it
+	Permalink(timestamp string) (string, error)
+}
+
+// ReplyOption an option for reply values
+type ReplyOption func(*ReplyDefaults)
+
+// WithUnfurlLinks sets whether links in the reply are unfurled into previews
+func WithUnfurlLinks(unfurl bool) ReplyOption {
+	return func(defaults *ReplyDefaults) {
+		defaults.UnfurlLinks = unfurl
+	}
+}
+
+// WithUnfurlMedia sets whether images and videos linked in the reply are unfurled into previews
+func WithUnfurlMedia(unfurl bool) ReplyOption {
+	return func(defaults *ReplyDefaults) {
+		defaults.UnfurlMedia = unfurl
+	}
+}
+
+// WithThreadTimestamp sets the thread the reply is posted in, given the timestamp of its parent message
+func WithThreadTimestamp(threadTimestamp string) ReplyOption {
+	return func(defaults *ReplyDefaults) {
+		defaults.ThreadTimestamp = threadTimestamp
+	}
+}
+
+// WithReplyBroadcast sets whether a reply posted in a thread is also shown in the channel
+func WithReplyBroadcast(broadcast bool) ReplyOption {
+	return func(defaults *ReplyDefaults) {
+		defaults.ReplyBroadcast = broadcast
+	}
+}
+
+// WithAttachments sets the attachments shown under the reply
+func WithAttachments(attachments ...slack.Attachment) ReplyOption {
+	return func(defaults *ReplyDefaults) {
+		defaults.Attachments = append(defaults.Attachments, attachments...)
+	}
+}
+
+// WithBlocks sets the Block Kit blocks the reply is laid out with, the text being shown in notifications
+func WithBlocks(blocks ...slack.Block) ReplyOption {
+	return func(defaults *ReplyDefaults) {
+		defaults.Blocks = append(defaults.Blocks, blocks...)
+	}
+}
+
+// ReplyDefaults configuration
+type ReplyDefaults struct {
+	UnfurlLinks     bool
+	UnfurlMedia     bool
+	ThreadTimestamp string
+	ReplyBroadcast  bool
+	Attachments     []slack.Attachment
+	Blocks          []slack.Block
+}
+
+// NewReplyDefaults applies the options to the default reply values
+func NewReplyDefaults(options ...ReplyOption) *ReplyDefaults {
+	params := slack.NewPostMessageParameters()
+	config := &ReplyDefaults{
+		UnfurlLinks: params.UnfurlLinks,
+		UnfurlMedia: params.UnfurlMedia,
+	}
+
+	for _, option := range options {
+		option(config)
+	}
+	return config
+}
+
+// msgOptions returns the options a reply with the defaults is posted with
+func (d *ReplyDefaults) msgOptions() []slack.MsgOption {
+	params := slack.NewPostMessageParameters()
+	params.UnfurlLinks = d.UnfurlLinks
+	params.UnfurlMedia = d.UnfurlMedia
+	if len(d.ThreadTimestamp) > 0 {
+		params.ThreadTimestamp = d.ThreadTimestamp
+		params.ReplyBroadcast = d.ReplyBroadcast
+	}
+	return []slack.MsgOption{
+		slack.MsgOptionPostMessageParameters(params),
+		slack.MsgOptionAttachments(d.Attachments...),
+		slack.MsgOptionBlocks(d.Blocks...),
+	}
+}
+
+// optionsLimiter is implemented by the responses that may not honor reply options, such as attachments
+type optionsLimiter interface {
+	supportsOptions() bool
+}
+
+// ResponseWriterConstructor creates the ResponseWriter for a message, given the one the framework would use
+type ResponseWriterConstructor func(event *slack.MessageEvent, response ResponseWriter) ResponseWriter
+
 // NewResponse creates a new response structure
-func NewResponse(channel string, RTM *slack.RTM) *Response {
-	return &Response{channel: channel, RTM: RTM}
+func NewResponse(channel string, sender MessageSender) *Response {
+	return &Response{channel: channel, sender: sender}
+}
+
+// newRTMResponse creates a response replying over the bot's connection, or through the Web API when
+// the reply has options the connection does not support
+func newRTMResponse(channel string, slacker *Slacker) *Response {
+	response := NewResponse(channel, &queueingSender{slacker: slacker})
+	response.slacker = slacker
+	return response
 }
 
-// Response contains the channel and Real Time Messaging library
+// Response contains the channel and the Real Time Messaging sender
 type Response struct {
 	channel string
-	RTM     *slack.RTM
+	sender  MessageSender
+	slacker *Slacker
 }
 
-// Reply send a message back to the channel where we received the event from
-func (r *Response) Reply(text string) {
-	r.RTM.SendMessage(r.RTM.NewOutgoingMessage(text, r.channel))
+// Reply send a message back to the channel where we received the event from.
+// Options are only honored when the response was created by the bot, as they require the Web API
+func (r *Response) Reply(text string, options ...ReplyOption) {
+	if len(options) > 0 && r.slacker != nil {
+		r.slacker.postMessage(r.channel, text, NewReplyDefaults(options...).msgOptions()...)
+		return
+	}
+	r.sender.SendMessage(r.sender.NewOutgoingMessage(text, r.channel))
+}
+
+// ReplyTemporary send a message back to the channel where we received the event from, deleting it once the TTL elapses.
+// Deleting requires the Web API, so nothing is sent when the response was not created by the bot
+func (r *Response) ReplyTemporary(text string, ttl time.Duration, options ...ReplyOption) error {
+	if r.slacker == nil {
+		return ErrCannotDelete
+	}
+	return r.slacker.postTemporary(r.channel, text, ttl, NewReplyDefaults(options...).msgOptions()...)
+}
+
+// ReplyTable send a table back to the channel where we received the event from.
+// Uploading requires the Web API, so the table is always replied in a code block when the response was not created by the bot
+func (r *Response) ReplyTable(headers []string, rows [][]string, options ...ReplyOption) error {
+	if r.slacker == nil {
+		r.Reply(format.CodeBlock(renderTable(headers, rows)), options...)
+		return nil
+	}
+	return r.slacker.postTable(r.channel, headers, rows, NewReplyDefaults(options...))
+}
+
+// ReplyImage send an image back to the channel where we received the event from.
+// Uploading requires the Web API, so it fails with ErrNoBotClient when the response was not created by the bot
+func (r *Response) ReplyImage(name string, png io.Reader, caption string, options ...ReplyOption) error {
+	if r.slacker == nil {
+		return ErrNoBotClient
+	}
+	return r.slacker.postImage(r.channel, name, png, caption, NewReplyDefaults(options...))
+}
+
+// Permalink returns a link to the message with the timestamp in the channel where we received the event from.
+// It requires the Web API, so it fails with ErrNoBotClient when the response was not created by the bot
+func (r *Response) Permalink(timestamp string) (string, error) {
+	if r.slacker == nil {
+		return empty, ErrNoBotClient
+	}
+	return r.slacker.Permalink(r.channel, timestamp)
+}
+
+// supportsOptions determines whether the reply options are honored, requiring the response to be created by the bot
+func (r *Response) supportsOptions() bool {
+	return r.slacker != nil
 }
 
 // ReportError sends back a formatted error message to the channel where we received the event from
 func (r *Response) ReportError(err error) {
-	r.RTM.SendMessage(r.RTM.NewOutgoingMessage(fmt.Sprintf(errorFormat, err.Error()), r.channel))
+	r.sender.SendMessage(r.sender.NewOutgoingMessage(fmt.Sprintf(errorFormat, err.Error()), r.channel))
 }
 
 // Typing send a typing indicator
 func (r *Response) Typing() {
-	r.RTM.SendMessage(r.RTM.NewTypingMessage(r.channel))
+	r.sender.SendMessage(r.sender.NewTypingMessage(r.channel))
+}
+
+// newAPIResponse creates a response that replies through the Web API, for when no RTM connection is in use
+func newAPIResponse(channel string, slacker *Slacker) *apiResponse {
+	return &apiResponse{channel: channel, slacker: slacker}
+}
+
+// apiResponse contains the channel and the client posting through the Web API,
+// along with the connection sending typing indicators, if any
+type apiResponse struct {
+	channel string
+	slacker *Slacker
+	sender  MessageSender
+	posted  func(timestamp string)
+}
+
+// Reply send a message back to the channel where we received the event from
+func (r *apiResponse) Reply(text string, options ...ReplyOption) {
+	timestamp, err := r.slacker.postMessageTimestamp(r.channel, text, NewReplyDefaults(options...).msgOptions()...)
+	if err == nil && len(timestamp) > 0 && r.posted != nil {
+		r.posted(timestamp)
+	}
+}
+
+// ReplyTemporary send a message back to the channel where we received the event from, deleting it once the TTL elapses
+func (r *apiResponse) ReplyTemporary(text string, ttl time.Duration, options ...ReplyOption) error {
+	return r.slacker.postTemporary(r.channel, text, ttl, NewReplyDefaults(options...).msgOptions()...)
+}
+
+// ReplyTable send a table back to the channel where we received the event from
+func (r *apiResponse) ReplyTable(headers []string, rows [][]string, options ...ReplyOption) error {
+	return r.slacker.postTable(r.channel, headers, rows, NewReplyDefaults(options...))
+}
+
+// ReplyImage send an image back to the channel where we received the event from
+func (r *apiResponse) ReplyImage(name string, png io.Reader, caption string, options ...ReplyOption) error {
+	return r.slacker.postImage(r.channel, name, png, caption, NewReplyDefaults(options...))
+}
+
+// Permalink returns a link to the message with the timestamp in the channel where we received the event from
+func (r *apiResponse) Permalink(timestamp string) (string, error) {
+	return r.slacker.Permalink(r.channel, timestamp)
+}
+
+// ReportError sends back a formatted error message to the channel where we received the event from
+func (r *apiResponse) ReportError(err error) {
+	r.slacker.postMessage(r.channel, fmt.Sprintf(errorFormat, err.Error()))
+}
+
+// trackPosts also reports the timestamps of the replies posted from now on to the callback
+func (r *apiResponse) trackPosts(posted func(timestamp string)) (func(), bool) {
+	previous := r.posted
+	r.posted = func(timestamp string) {
+		if previous != nil {
+			previous(timestamp)
+		}
+		posted(timestamp)
+	}
+
+	untrack := func() {
+		r.posted = previous
+	}
+	return untrack, true
+}
+
+// Typing send a typing indicator over the connection, the Web API does not support them
+func (r *apiResponse) Typing() {
+	if r.sender == nil {
+		return
+	}
+	r.sender.SendMessage(r.sender.NewTypingMessage(r.channel))
 }
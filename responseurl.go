@@ -0,0 +1,79 @@
+package slacker
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/shomali11/slacker/format"
+)
+
+const (
+	inChannelResponseType = "in_channel"
+)
+
+// newResponseURLResponse creates a response replying through the response URL of a slash command or interaction,
+// which works for up to half an hour even in channels the bot is not a member of
+func newResponseURLResponse(responseURL string, channel string, slacker *Slacker) *responseURLResponse {
+	return &responseURLResponse{webhook: WebhookWithClient(responseURL, slacker.defaults.HTTPClient), channel: channel, slacker: slacker}
+}
+
+// responseURLResponse posts replies to a response URL as messages visible to the whole channel,
+// along with the channel files are uploaded to through the bot client
+type responseURLResponse struct {
+	webhook *WebhookSender
+	channel string
+	slacker *Slacker
+}
+
+// Reply send a message back to the channel where we received the event from
+func (r *responseURLResponse) Reply(text string, options ...ReplyOption) {
+	defaults := NewReplyDefaults(options...)
+	r.post(&webhookMessage{Text: text, UnfurlLinks: defaults.UnfurlLinks, UnfurlMedia: defaults.UnfurlMedia, Attachments: defaults.Attachments, Blocks: defaults.Blocks})
+}
+
+// ReplyTemporary fails with ErrCannotDelete, as messages posted to response URLs cannot be deleted
+func (r *responseURLResponse) ReplyTemporary(text string, ttl time.Duration, options ...ReplyOption) error {
+	return ErrCannotDelete
+}
+
+// ReplyTable send a table back to the channel where we received the event from.
+// Files cannot be posted to response URLs, so the table is always replied in a code block
+func (r *responseURLResponse) ReplyTable(headers []string, rows [][]string, options ...ReplyOption) error {
+	r.Reply(format.CodeBlock(renderTable(headers, rows)), options...)
+	return nil
+}
+
+// ReplyImage send an image back to the channel where we received the event from.
+// Files cannot be posted to response URLs, so the image is uploaded through the bot client,
+// which requires the bot to be a member of the channel
+func (r *responseURLResponse) ReplyImage(name string, png io.Reader, caption string, options ...ReplyOption) error {
+	return r.slacker.postImage(r.channel, name, png, caption, NewReplyDefaults(options...))
+}
+
+// Permalink returns a link to the message with the timestamp in the channel where we received the event from
+func (r *responseURLResponse) Permalink(timestamp string) (string, error) {
+	return r.slacker.Permalink(r.channel, timestamp)
+}
+
+// ReportError sends back a formatted error message to the channel where we received the event from
+func (r *responseURLResponse) ReportError(err error) {
+	defaults := NewReplyDefaults()
+	r.post(&webhookMessage{Text: fmt.Sprintf(errorFormat, err.Error()), UnfurlLinks: defaults.UnfurlLinks, UnfurlMedia: defaults.UnfurlMedia})
+}
+
+// Typing is not supported by response URLs, so it does nothing
+func (r *responseURLResponse) Typing() {}
+
+func (r *responseURLResponse) post(message *webhookMessage) {
+	message.ResponseType = inChannelResponseType
+	if r.slacker.isDryRun() {
+		r.slacker.defaults.DryRunLogger.Printf(dryRunMessageFormat, r.webhook.url, message.Text)
+		return
+	}
+
+	err := r.webhook.post(message)
+	if err != nil {
+		r.slacker.reportError(err)
+	}
+}
@@ -0,0 +1,132 @@
+package slacker
+
+import (
+	"fmt"
+
+	"github.com/shomali11/slacker/format"
+	"github.com/slack-go/slack"
+)
+
+const (
+	successColor = "good"
+	warningColor = "warning"
+	failureColor = "danger"
+	infoColor    = "#439FE0"
+	markdownText = "text"
+	fieldFormat  = "%s: %s"
+)
+
+// ResultStatus is the outcome of a command, deciding the color its result is rendered with
+type ResultStatus int
+
+const (
+	// ResultSuccess is a command that succeeded, rendered in green
+	ResultSuccess ResultStatus = iota
+
+	// ResultWarning is a command that succeeded with caveats, rendered in yellow
+	ResultWarning
+
+	// ResultFailure is a command that failed, rendered in red
+	ResultFailure
+
+	// ResultInfo is a command that only reports information, rendered in blue
+	ResultInfo
+)
+
+// ResultField is a titled value shown in a result, side by side with the next one when short
+type ResultField struct {
+	Title string
+	Value string
+	Short bool
+}
+
+// Result is what a command returns for the framework to render, giving all commands the same look
+type Result struct {
+	Status  ResultStatus
+	Summary string
+	Details string
+	Fields  []*ResultField
+}
+
+// NewResult creates a result with the status and summary
+func NewResult(status ResultStatus, summary string) *Result {
+	return &Result{Status: status, Summary: summary}
+}
+
+// WithDetails sets the text shown under the summary
+func (r *Result) WithDetails(details string) *Result {
+	r.Details = details
+	return r
+}
+
+// Field adds a titled value, shown side by side with the next short one
+func (r *Result) Field(title string, value string, short bool) *Result {
+	r.Fields = append(r.Fields, &ResultField{Title: title, Value: value, Short: short})
+	return r
+}
+
+// attachment renders the result as an attachment colored by its status
+func (r *Result) attachment() slack.Attachment {
+	attachment := slack.Attachment{
+		Color:      r.color(),
+		Fallback:   r.Summary,
+		Title:      r.Summary,
+		Text:       r.Details,
+		MarkdownIn: []string{markdownText},
+	}
+
+	for _, field := range r.Fields {
+		attachment.Fields = append(attachment.Fields, slack.AttachmentField{Title: field.Title, Value: field.Value, Short: field.Short})
+	}
+	return attachment
+}
+
+// text renders the result as text, for responses that cannot send attachments
+func (r *Result) text() string {
+	text := format.Bold(r.Summary) + newLine
+	if len(r.Details) > 0 {
+		text += r.Details + newLine
+	}
+
+	for _, field := range r.Fields {
+		text += fmt.Sprintf(fieldFormat, format.Bold(field.Title), field.Value) + newLine
+	}
+	return text
+}
+
+func (r *Result) color() string {
+	switch r.Status {
+	case ResultWarning:
+		return warningColor
+
+	case ResultFailure:
+		return failureColor
+
+	case ResultInfo:
+		return infoColor
+	}
+	return successColor
+}
+
+// ReplyResult replies the result as an attachment colored by its status,
+// or as text when the response cannot send attachments
+func ReplyResult(response ResponseWriter, result *Result, options ...ReplyOption) {
+	if result == nil {
+		return
+	}
+
+	limited, ok := response.(optionsLimiter)
+	if ok && !limited.supportsOptions() {
+		response.Reply(result.text())
+		return
+	}
+	response.Reply(empty, append(options, WithAttachments(result.attachment()))...)
+}
+
+// ResultCommand define a new command whose handler returns a result, rendered as an attachment colored by its status.
+// Nothing is replied when the handler returns nil
+func (s *Slacker) ResultCommand(usage string, description string, handler func(request *Request) *Result, options ...CommandOption) {
+	s.Command(usage, description, func(request *Request, response ResponseWriter) {
+		ReplyResult(response, handler(request))
+	}, options...)
+}
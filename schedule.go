@@ -0,0 +1,97 @@
+package slacker
+
+import (
+	"time"
+)
+
+// Schedule decides when recurring work runs next
+type Schedule interface {
+	// Next returns the first time the work should run after the given time
+	Next(after time.Time) time.Time
+}
+
+// Every creates a schedule running at a fixed interval
+func Every(interval time.Duration) Schedule {
+	return intervalSchedule(interval)
+}
+
+// Daily creates a schedule running every day at the hour and minute in the location, on the given weekdays.
+// Without weekdays it runs every day
+func Daily(hour int, minute int, location *time.Location, weekdays ...time.Weekday) Schedule {
+	return &dailySchedule{hour: hour, minute: minute, location: location, weekdays: weekdays}
+}
+
+// intervalSchedule runs at a fixed interval
+type intervalSchedule time.Duration
+
+// Next returns the given time plus the interval
+func (i intervalSchedule) Next(after time.Time) time.Time {
+	return after.Add(time.Duration(i))
+}
+
+// dailySchedule runs at a time of day, optionally only on some weekdays
+type dailySchedule struct {
+	hour     int
+	minute   int
+	location *time.Location
+	weekdays []time.Weekday
+}
+
+// Next returns the first matching time of day after the given time
+func (d *dailySchedule) Next(after time.Time) time.Time {
+	location := d.location
+	if location == nil {
+		location = time.Local
+	}
+
+	local := after.In(location)
+	next := time.Date(local.Year(), local.Month(), local.Day(), d.hour, d.minute, 0, 0, location)
+	for !next.After(local) || !d.isWeekday(next.Weekday()) {
+		next = time.Date(next.Year(), next.Month(), next.Day()+1, d.hour, d.minute, 0, 0, location)
+	}
+	return next
+}
+
+func (d *dailySchedule) isWeekday(weekday time.Weekday) bool {
+	if len(d.weekdays) == 0 {
+		return true
+	}
+
+	for _, day := range d.weekdays {
+		if day == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// runScheduled calls the function at every time of the schedule until the bot is closed
+func (s *Slacker) runScheduled(schedule Schedule, fn func()) {
+	go func() {
+		for {
+			timer := time.NewTimer(time.Until(schedule.Next(time.Now())))
+			select {
+			case <-s.done:
+				timer.Stop()
+				return
+
+			case <-timer.C:
+				s.goHandle(fn)
+			}
+		}
+	}()
+}
+
+// runAt calls the function once at the time, unless the bot is closed first
+func (s *Slacker) runAt(at time.Time, fn func()) {
+	go func() {
+		timer := time.NewTimer(time.Until(at))
+		select {
+		case <-s.done:
+			timer.Stop()
+
+		case <-timer.C:
+			s.goHandle(fn)
+		}
+	}()
+}
@@ -0,0 +1,104 @@
+package slacker
+
+import (
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	defaultSendRetries      = 3
+	defaultSendInitialDelay = time.Second
+)
+
+// permanentSendErrors are the Web API errors for which retrying a message cannot help
+var permanentSendErrors = map[string]bool{
+	"channel_not_found": true,
+	"not_in_channel":    true,
+	"is_archived":       true,
+	"msg_too_long":      true,
+	"no_text":           true,
+	"restricted_action": true,
+	"missing_scope":     true,
+	"invalid_auth":      true,
+	"not_authed":        true,
+	"account_inactive":  true,
+	"token_revoked":     true,
+}
+
+// OnSendFailure handle messages that could not be sent, even after retrying
+func (s *Slacker) OnSendFailure(sendFailureHandler func(channel string, text string, err error)) {
+	s.sendFailureHandler = sendFailureHandler
+}
+
+// postMessage posts a message through the Web API, retrying failures with exponential backoff.
+// Messages that still could not be sent are queued when a store is available and the failure may be temporary,
+// in which case no error is returned, or else passed to the send failure handler
+func (s *Slacker) postMessage(channel string, text string, options ...slack.MsgOption) error {
+	_, err := s.postMessageTimestamp(channel, text, options...)
+	return err
+}
+
+// postMessageTimestamp is like postMessage, also returning the timestamp of the posted message,
+// which is empty when the message was queued
+func (s *Slacker) postMessageTimestamp(channel string, text string, options ...slack.MsgOption) (string, error) {
+	timestamp, err := s.postWithRetries(channel, text, options...)
+	if err == nil {
+		return timestamp, nil
+	}
+
+	// Messages that may still be delivered later are kept when a store is available
+	if s.defaults.Store != nil && !permanentSendErrors[err.Error()] {
+		queueErr := s.enqueueMessage(channel, text)
+		if queueErr == nil {
+			return empty, nil
+		}
+		s.reportError(queueErr)
+	}
+
+	s.sendFailed(channel, text, err)
+	return empty, err
+}
+
+// postWithRetries posts a message through the Web API, retrying failures with exponential backoff.
+// Retrying stops once the bot is closed, returning the last failure
+func (s *Slacker) postWithRetries(channel string, text string, options ...slack.MsgOption) (string, error) {
+	delay := s.defaults.SendInitialDelay
+	options = append([]slack.MsgOption{slack.MsgOptionText(text, false)}, options...)
+
+	var timestamp string
+	var err error
+	for attempt := 0; attempt <= s.defaults.SendRetries; attempt++ {
+		_, timestamp, err = s.poster.PostMessage(channel, options...)
+		if err == nil || permanentSendErrors[err.Error()] {
+			break
+		}
+
+		wait := delay
+		if rateLimited, ok := err.(*slack.RateLimitedError); ok {
+			wait = rateLimited.RetryAfter
+		}
+
+		if attempt == s.defaults.SendRetries {
+			break
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-s.ctx.Done():
+			timer.Stop()
+			return timestamp, err
+		}
+		delay *= 2
+	}
+
+	return timestamp, err
+}
+
+// sendFailed passes a message that could not be sent to the send failure handler, if any
+func (s *Slacker) sendFailed(channel string, text string, err error) {
+	if s.sendFailureHandler != nil {
+		s.sendFailureHandler(channel, text, err)
+	}
+}
@@ -0,0 +1,144 @@
+package slacker
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	sessionKeyFormat  = "slacker:session:%s:%s:%s"
+	defaultSessionTTL = 24 * time.Hour
+)
+
+// sessionLocks serializes the changes to each session within the process
+var sessionLocks = newKeyLocks()
+
+// Session is a key-value session scoped to a user in a channel, persisted in the store.
+// Every change is saved right away and extends the session's lifetime. Changes are applied to the stored session,
+// so those made by concurrent handlers are not lost
+type Session struct {
+	key    string
+	store  Store
+	ttl    time.Duration
+	values map[string]string
+}
+
+// loadSession loads the session stored under the key, starting an empty one if there is none
+func loadSession(store Store, key string, ttl time.Duration) (*Session, error) {
+	values, err := loadSessionValues(store, key)
+	return &Session{key: key, store: store, ttl: ttl, values: values}, err
+}
+
+// loadSessionValues returns the values of the session stored under the key, or none if there is no session
+func loadSessionValues(store Store, key string) (map[string]string, error) {
+	values := make(map[string]string)
+	data, ok, err := store.Get(key)
+	if err != nil || !ok {
+		return values, err
+	}
+	return values, json.Unmarshal(data, &values)
+}
+
+// Get returns the value stored under the key and whether it was found
+func (s *Session) Get(key string) (string, bool) {
+	value, ok := s.values[key]
+	return value, ok
+}
+
+// Set stores the value under the key and saves the session
+func (s *Session) Set(key string, value string) error {
+	return s.update(func(values map[string]string) {
+		values[key] = value
+	})
+}
+
+// Delete removes the key and saves the session
+func (s *Session) Delete(key string) error {
+	return s.update(func(values map[string]string) {
+		delete(values, key)
+	})
+}
+
+// Clear removes all the keys and the session itself
+func (s *Session) Clear() error {
+	unlock := sessionLocks.lock(s.key)
+	defer unlock()
+
+	s.values = make(map[string]string)
+	return s.store.Delete(s.key)
+}
+
+// update applies the change to the stored session and saves it, holding the session's lock
+func (s *Session) update(change func(values map[string]string)) error {
+	unlock := sessionLocks.lock(s.key)
+	defer unlock()
+
+	values, err := loadSessionValues(s.store, s.key)
+	if err != nil {
+		return err
+	}
+
+	change(values)
+	s.values = values
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	return s.store.Set(s.key, data, s.ttl)
+}
+
+// sessionStore returns the configured store, or the in-memory one
+func (s *Slacker) sessionStore() Store {
+	if s.defaults.Store != nil {
+		return s.defaults.Store
+	}
+	return defaultStore
+}
+
+// sessionKey returns the key of the session of the user in the channel
+func sessionKey(teamID string, channelID string, userID string) string {
+	return fmt.Sprintf(sessionKeyFormat, teamID, channelID, userID)
+}
+
+// keyLocks holds a lock for each key in use, forgetting it once no one holds it
+type keyLocks struct {
+	mutex sync.Mutex
+	locks map[string]*keyLock
+}
+
+// keyLock is the lock of a key, along with the number of those holding or waiting for it
+type keyLock struct {
+	sync.Mutex
+	holders int
+}
+
+func newKeyLocks() *keyLocks {
+	return &keyLocks{locks: make(map[string]*keyLock)}
+}
+
+// lock locks the key, returning the function unlocking it
+func (l *keyLocks) lock(key string) func() {
+	l.mutex.Lock()
+	lock, ok := l.locks[key]
+	if !ok {
+		lock = &keyLock{}
+		l.locks[key] = lock
+	}
+	lock.holders++
+	l.mutex.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+
+		l.mutex.Lock()
+		lock.holders--
+		if lock.holders == 0 {
+			delete(l.locks, key)
+		}
+		l.mutex.Unlock()
+	}
+}
@@ -0,0 +1,74 @@
+package slacker
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+const (
+	shutdownTimeout = "timed out waiting for handlers to finish"
+	botClosed       = "the bot is closed"
+)
+
+// Close stops receiving events and cancels the contexts of running handlers. Use Wait to let them finish
+func (s *Slacker) Close() error {
+	s.closeOnce.Do(func() {
+		// No handler starts once the bot is closed, so that Wait cannot miss any
+		s.handlersMutex.Lock()
+		close(s.done)
+		s.handlersMutex.Unlock()
+		s.cancel()
+
+		// Disconnecting also stops the connection from being retried, if it was never established.
+		// Only the connection Listen manages can be disconnected, and it may be busy delivering an event,
+		// so closing does not wait for it
+		if atomic.LoadInt32(&s.rtmStarted) == 1 {
+			go s.RTM.Disconnect()
+		}
+	})
+	return nil
+}
+
+// Wait blocks until the bot is closed and its running handlers finish, giving up once the grace period has passed
+func (s *Slacker) Wait() error {
+	<-s.done
+
+	finished := make(chan struct{})
+	go func() {
+		s.handlers.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		return nil
+	case <-time.After(s.defaults.ShutdownGracePeriod):
+		return ErrShutdownTimeout
+	}
+}
+
+// goHandle runs the handler in its own goroutine, keeping track of it until it returns.
+// Handlers are dropped once the bot is closed
+func (s *Slacker) goHandle(handler func()) {
+	s.handlersMutex.Lock()
+	defer s.handlersMutex.Unlock()
+
+	if s.isClosed() {
+		return
+	}
+
+	s.handlers.Add(1)
+	go func() {
+		defer s.handlers.Done()
+		handler()
+	}()
+}
+
+func (s *Slacker) isClosed() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
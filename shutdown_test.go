@@ -0,0 +1,69 @@
+package slacker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloseHandlers(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler func(bot *Slacker) http.Handler
+	}{
+		{name: "events", handler: func(bot *Slacker) http.Handler { return bot.EventsHandler(context.Background()) }},
+		{name: "slash commands", handler: func(bot *Slacker) http.Handler { return bot.SlashCommandsHandler(context.Background()) }},
+		{name: "interactions", handler: func(bot *Slacker) http.Handler { return bot.InteractionsHandler() }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bot := NewClient("xoxb-test", WithSigningSecret(testSigningSecret))
+			handler := test.handler(bot)
+
+			closed := make(chan error, 1)
+			go func() {
+				closed <- bot.Close()
+			}()
+
+			select {
+			case err := <-closed:
+				assert.NoError(t, err)
+			case <-time.After(time.Second):
+				t.Fatal("Close did not return")
+			}
+			assert.NoError(t, bot.Close())
+
+			now := strconv.FormatInt(time.Now().Unix(), 10)
+			request := httptest.NewRequest(http.MethodPost, "/slack", strings.NewReader(testBody))
+			request.Header.Set(requestTimestampHeader, now)
+			request.Header.Set(signatureHeader, sign(testSigningSecret, now, testBody))
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+		})
+	}
+}
+
+func TestBroadcastAfterClose(t *testing.T) {
+	poster := &fakePoster{}
+	bot := NewClient("xoxb-test", WithMessagePoster(poster))
+
+	assert.NoError(t, bot.Broadcast([]string{"C1"}, "first", WithBroadcastInterval(time.Hour)))
+	assert.Equal(t, 1, poster.posted)
+
+	go bot.Close()
+
+	err := bot.Broadcast([]string{"C1", "C2"}, "second", WithBroadcastInterval(time.Hour))
+	broadcastErr, ok := err.(*BroadcastError)
+	if assert.True(t, ok, err) {
+		assert.Equal(t, ErrClosed, broadcastErr.Failures["C2"])
+	}
+}
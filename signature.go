@@ -0,0 +1,97 @@
+package slacker
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	signatureHeader        = "X-Slack-Signature"
+	requestTimestampHeader = "X-Slack-Request-Timestamp"
+	signatureVersion       = "v0"
+	signatureSeparator     = ":"
+	signaturePrefix        = signatureVersion + "="
+	defaultReplayWindow    = 5 * time.Minute
+	maxRequestBodySize     = 1 << 20
+	invalidSignature       = "request signature is invalid"
+	staleRequest           = "request timestamp is outside the replay window"
+	noSigningSecret        = "no signing secret is configured to verify requests"
+)
+
+// OnRejectedRequest handle requests rejected by the signature verification, for example to log or count them
+func (s *Slacker) OnRejectedRequest(rejectedRequestHandler func(request *http.Request, err error)) {
+	s.rejectedRequestHandler = rejectedRequestHandler
+}
+
+// VerifySignature returns a handler rejecting requests not signed by Slack with the signing secret set by WithSigningSecret,
+// or signed longer ago than the replay window, before passing them on to the next handler. The handlers served by the bot are already verified this way.
+// Without a signing secret, every request is rejected with ErrNoSigningSecret
+func (s *Slacker) VerifySignature(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if len(s.defaults.SigningSecret) == 0 {
+			s.rejectRequest(writer, request, ErrNoSigningSecret)
+			return
+		}
+
+		// Bodies are read before being verified, so their size is limited
+		body, err := ioutil.ReadAll(http.MaxBytesReader(writer, request.Body, maxRequestBodySize))
+		if err != nil {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		timestamp := request.Header.Get(requestTimestampHeader)
+		if !s.isSignatureValid(timestamp, body, request.Header.Get(signatureHeader)) {
+			s.rejectRequest(writer, request, ErrInvalidSignature)
+			return
+		}
+
+		// A valid signature of an old request is a captured request being replayed
+		if !s.isTimestampRecent(timestamp) {
+			s.rejectRequest(writer, request, ErrStaleRequest)
+			return
+		}
+
+		request.Body = ioutil.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(writer, request)
+	})
+}
+
+// isSignatureValid determines whether the signature is the HMAC of the timestamp and body with the signing secret
+func (s *Slacker) isSignatureValid(timestamp string, body []byte, signature string) bool {
+	mac := hmac.New(sha256.New, []byte(s.defaults.SigningSecret))
+	mac.Write([]byte(signatureVersion + signatureSeparator + timestamp + signatureSeparator))
+	mac.Write(body)
+
+	expected := signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// isTimestampRecent determines whether the request timestamp, in seconds since the epoch, is within the replay window
+func (s *Slacker) isTimestampRecent(timestamp string) bool {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	age := time.Since(time.Unix(seconds, 0))
+	if age < 0 {
+		age = -age
+	}
+	return age <= s.defaults.ReplayWindow
+}
+
+func (s *Slacker) rejectRequest(writer http.ResponseWriter, request *http.Request, err error) {
+	writer.WriteHeader(http.StatusUnauthorized)
+
+	if s.rejectedRequestHandler == nil {
+		return
+	}
+	s.rejectedRequestHandler(request, err)
+}
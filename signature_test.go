@@ -0,0 +1,81 @@
+package slacker
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testSigningSecret = "secret"
+	testBody          = "token=abc&text=deploy"
+)
+
+func sign(secret string, timestamp string, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signatureVersion + signatureSeparator + timestamp + signatureSeparator + body))
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifySignature(t *testing.T) {
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	old := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		signature string
+		body      string
+		status    int
+		err       error
+	}{
+		{name: "valid", secret: testSigningSecret, timestamp: now, signature: sign(testSigningSecret, now, testBody), body: testBody, status: http.StatusOK},
+		{name: "tampered body", secret: testSigningSecret, timestamp: now, signature: sign(testSigningSecret, now, testBody), body: testBody + "&admin=true", status: http.StatusUnauthorized, err: ErrInvalidSignature},
+		{name: "wrong secret", secret: testSigningSecret, timestamp: now, signature: sign("other", now, testBody), body: testBody, status: http.StatusUnauthorized, err: ErrInvalidSignature},
+		{name: "missing signature", secret: testSigningSecret, timestamp: now, body: testBody, status: http.StatusUnauthorized, err: ErrInvalidSignature},
+		{name: "stale", secret: testSigningSecret, timestamp: old, signature: sign(testSigningSecret, old, testBody), body: testBody, status: http.StatusUnauthorized, err: ErrStaleRequest},
+		{name: "oversized", secret: testSigningSecret, timestamp: now, signature: sign(testSigningSecret, now, testBody), body: strings.Repeat("a", maxRequestBodySize+1), status: http.StatusBadRequest},
+		{name: "no secret", timestamp: now, signature: sign(empty, now, testBody), body: testBody, status: http.StatusUnauthorized, err: ErrNoSigningSecret},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bot := NewClient("xoxb-test", WithSigningSecret(test.secret))
+
+			var rejected error
+			bot.OnRejectedRequest(func(request *http.Request, err error) {
+				rejected = err
+			})
+
+			var received string
+			handler := bot.VerifySignature(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				body, err := ioutil.ReadAll(request.Body)
+				assert.NoError(t, err)
+				received = string(body)
+			}))
+
+			request := httptest.NewRequest(http.MethodPost, "/slack/commands", strings.NewReader(test.body))
+			request.Header.Set(requestTimestampHeader, test.timestamp)
+			request.Header.Set(signatureHeader, test.signature)
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			assert.Equal(t, test.status, recorder.Code)
+			assert.Equal(t, test.err, rejected)
+			if test.status == http.StatusOK {
+				assert.Equal(t, test.body, received)
+			}
+		})
+	}
+}
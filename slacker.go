@@ -4,46 +4,191 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
-	"github.com/nlopes/slack"
 	"github.com/shomali11/proper"
+	"github.com/shomali11/slacker/format"
+	"github.com/slack-go/slack"
 )
 
 const (
-	space               = " "
-	dash                = "-"
-	newLine             = "\n"
-	invalidToken        = "invalid token"
-	helpCommand         = "help"
-	directChannelMarker = "D"
-	userMentionFormat   = "<@%s>"
-	codeMessageFormat   = "`%s`"
-	boldMessageFormat   = "*%s*"
-	italicMessageFormat = "_%s_"
-	slackBotUser        = "USLACKBOT"
+	space                 = " "
+	dash                  = "-"
+	newLine               = "\n"
+	reconnectFailedFormat = "%w: failed to connect after %d attempts: %v"
+	helpCommand           = "help"
+	helpTopicParam        = "command"
+	unknownHelpTopic      = "No command matches %s"
+	directChannelMarker   = "D"
+	slackBotUser          = "USLACKBOT"
+	messageChanged        = "message_changed"
+	messageDeleted        = "message_deleted"
+	botMessageSubType     = "bot_message"
 )
 
 // NewClient creates a new client using the Slack API
-func NewClient(token string) *Slacker {
-	client := slack.New(token)
+func NewClient(token string, options ...ClientOption) *Slacker {
+	defaults := newClientDefaults(token, options...)
+	client := newAPIClient(token, defaults)
 	slacker := &Slacker{
-		Client: client,
-		RTM:    client.NewRTM(),
+		token:               token,
+		Client:              client,
+		defaults:            defaults,
+		eventCache:          newTTLCache(eventDeduplicationTTL),
+		unmatchedMessages:   newTTLCache(unmatchedMessageTTL),
+		failedTeams:         newTTLCache(teamFailureTTL),
+		failedChannels:      newTTLCache(channelFailureTTL),
+		workflowSteps:       make(map[string]*WorkflowStep),
+		conversations:       make(map[string]*Channel),
+		reactionMenus:       make(map[string]*reactionMenu),
+		botMessages:         make(map[string]*botMessage),
+		optionsProviders:    make(map[string]OptionsProvider),
+		forms:               make(map[string]*formSession),
+		botMessageReactions: make(map[string]func(reaction *BotMessageReaction)),
+		prompts:             make(map[string]*promptCollection),
+		users:               newUserCache(userCacheTTL),
+		presences:           make(map[string]string),
+		teams:               make(map[string]*Team),
+		enterprises:         make(map[string]string),
+		unfurlers:           make(map[string]Unfurler),
+		done:                make(chan struct{}),
+		startedAt:           time.Now(),
+	}
+
+	// The context of waits not tied to a handler, such as message retries, is canceled once the bot is closed
+	slacker.ctx, slacker.cancel = context.WithCancel(context.Background())
+
+	slacker.RTM = newRTM(token, slacker)
+	slacker.sender = slacker.RTM
+	slacker.info = slacker.RTM
+
+	rotating := &rotatingClient{slacker: slacker}
+	slacker.poster = rotating
+	slacker.authenticator = rotating
+	slacker.client.Store(client)
+
+	if slacker.defaults.MessageSender != nil {
+		slacker.sender = slacker.defaults.MessageSender
+	}
+
+	if slacker.defaults.InfoProvider != nil {
+		slacker.info = slacker.defaults.InfoProvider
+	}
+
+	if slacker.defaults.MessagePoster != nil {
+		slacker.poster = slacker.defaults.MessagePoster
+	}
+
+	if slacker.defaults.Authenticator != nil {
+		slacker.authenticator = slacker.defaults.Authenticator
+	}
+
+	if slacker.defaults.FloodWindow > 0 {
+		slacker.floodCache = newTTLCache(slacker.defaults.FloodWindow)
+	}
+
+	if slacker.isDryRun() {
+		slacker.sender = &dryRunSender{MessageSender: slacker.sender, logger: slacker.defaults.DryRunLogger}
+		slacker.poster = &dryRunPoster{logger: slacker.defaults.DryRunLogger}
 	}
 	return slacker
 }
 
 // Slacker contains the Slack API, botCommands, and handlers
 type Slacker struct {
-	Client                *slack.Client
-	RTM                   *slack.RTM
-	botCommands           []*BotCommand
-	initHandler           func()
-	errorHandler          func(err string)
-	helpHandler           func(request *Request, response ResponseWriter)
-	defaultMessageHandler func(request *Request, response ResponseWriter)
-	defaultEventHandler   func(interface{})
+	Client                     *slack.Client
+	RTM                        *slack.RTM
+	sender                     MessageSender
+	info                       InfoProvider
+	poster                     MessagePoster
+	authenticator              Authenticator
+	botCommands                []*BotCommand
+	connectedHandler           func(status *ConnectionStatus)
+	disconnectedHandler        func(status *ConnectionStatus)
+	initHandler                func()
+	errorHandler               func(err string)
+	handlerErrorHandler        func(ctx context.Context, request *Request, err error)
+	helpHandler                func(request *Request, response ResponseWriter)
+	defaultMessageHandler      func(request *Request, response ResponseWriter)
+	fallbackHandlers           []func(request *Request, response ResponseWriter) bool
+	keywordTriggers            []*keywordTrigger
+	actionRoutes               []*actionRoute
+	optionsProviders           map[string]OptionsProvider
+	optionsMutex               sync.Mutex
+	forms                      map[string]*formSession
+	formID                     int
+	formsMutex                 sync.Mutex
+	interactive                int32
+	defaultEventHandler        func(interface{})
+	messageEditedHandler       func(request *Request, response ResponseWriter)
+	messageDeletedHandler      func(channel string, timestamp string)
+	appHomeOpenedHandler       func(event *AppHomeOpenedEvent)
+	memberJoinedChannelHandler func(event *MemberJoinedChannelEvent)
+	fileSharedHandler          func(event *FileSharedEvent)
+	presenceChangeHandler      func(event *PresenceChangeEvent)
+	userChangeHandler          func(user *slack.User)
+	presences                  map[string]string
+	presenceMutex              sync.Mutex
+	responseConstructor        ResponseWriterConstructor
+	rejectedRequestHandler     func(request *http.Request, err error)
+	sendFailureHandler         func(channel string, text string, err error)
+	workflowSteps              map[string]*WorkflowStep
+	conversations              map[string]*Channel
+	conversationsMutex         sync.Mutex
+	teams                      map[string]*Team
+	enterprises                map[string]string
+	teamsMutex                 sync.Mutex
+	reactionMenus              map[string]*reactionMenu
+	unfurlers                  map[string]Unfurler
+	reactionMenusMutex         sync.Mutex
+	botMessages                map[string]*botMessage
+	botMessageReactions        map[string]func(reaction *BotMessageReaction)
+	botMessagesMutex           sync.Mutex
+	pollsMutex                 sync.Mutex
+	prompts                    map[string]*promptCollection
+	promptsMutex               sync.Mutex
+	handlers                   sync.WaitGroup
+	handlersMutex              sync.Mutex
+	done                       chan struct{}
+	ctx                        context.Context
+	cancel                     context.CancelFunc
+	connected                  int32
+	rtmStarted                 int32
+	queueMutex                 sync.Mutex
+	temporaryMutex             sync.Mutex
+	deadLetters                []*DeadLetter
+	deadLetterID               int
+	deadLettersMutex           sync.Mutex
+	closeOnce                  sync.Once
+	token                      string
+	tokenMutex                 sync.Mutex
+	client                     atomic.Value
+	defaults                   *ClientDefaults
+	eventCache                 *ttlCache
+	floodCache                 *ttlCache
+	failedTeams                *ttlCache
+	failedChannels             *ttlCache
+	unmatchedMessages          *ttlCache
+	users                      *userCache
+	stats                      commandStats
+	subscribers                map[string][]func(event *BusEvent)
+	connection                 connectionTracker
+	latency                    atomic.Value
+	reconnectDelay             atomic.Value
+	latencyHandler             func(latency time.Duration)
+	subscribersMutex           sync.Mutex
+	maintenance                maintenance
+	startedAt                  time.Time
+	connections                int32
+	errors                     int32
+	helpOnce                   sync.Once
+	startOnce                  sync.Once
+	authOnce                   sync.Once
+	userID                     string
 }
 
 // Init handle the event when the bot is first connected
@@ -56,133 +201,550 @@ func (s *Slacker) Err(errorHandler func(err string)) {
 	s.errorHandler = errorHandler
 }
 
+// OnHandlerError handle commands that failed by reporting an error or panicking, for example to notify an error tracker
+func (s *Slacker) OnHandlerError(handlerErrorHandler func(ctx context.Context, request *Request, err error)) {
+	s.handlerErrorHandler = handlerErrorHandler
+}
+
 // DefaultCommand handle messages when none of the commands are matched
 func (s *Slacker) DefaultCommand(defaultMessageHandler func(request *Request, response ResponseWriter)) {
 	s.defaultMessageHandler = defaultMessageHandler
 }
 
+// Fallback adds a handler for messages when none of the commands are matched. Fallbacks are evaluated in the order
+// they were added until one claims the message by returning true, and the DefaultCommand handles the unclaimed ones
+func (s *Slacker) Fallback(fallbackHandler func(request *Request, response ResponseWriter) bool) {
+	s.fallbackHandlers = append(s.fallbackHandlers, fallbackHandler)
+}
+
 // DefaultEvent handle events when an unknown event is seen
 func (s *Slacker) DefaultEvent(defaultEventHandler func(interface{})) {
 	s.defaultEventHandler = defaultEventHandler
 }
 
+// OnMessageEdited handle messages that were edited after being sent
+func (s *Slacker) OnMessageEdited(messageEditedHandler func(request *Request, response ResponseWriter)) {
+	s.messageEditedHandler = messageEditedHandler
+}
+
+// OnMessageDeleted handle messages that were deleted, receiving the channel and timestamp of the deleted message
+func (s *Slacker) OnMessageDeleted(messageDeletedHandler func(channel string, timestamp string)) {
+	s.messageDeletedHandler = messageDeletedHandler
+}
+
+// CustomResponse replaces the ResponseWriter given to handlers, for example to wrap or record replies
+func (s *Slacker) CustomResponse(responseConstructor ResponseWriterConstructor) {
+	s.responseConstructor = responseConstructor
+}
+
 // Help handle the help message, it will use the default if not set
 func (s *Slacker) Help(helpHandler func(request *Request, response ResponseWriter)) {
 	s.helpHandler = helpHandler
 }
 
 // Command define a new command and append it to the list of existing commands
-func (s *Slacker) Command(usage string, description string, handler func(request *Request, response ResponseWriter)) {
-	s.botCommands = append(s.botCommands, NewBotCommand(usage, description, handler))
+func (s *Slacker) Command(usage string, description string, handler func(request *Request, response ResponseWriter), options ...CommandOption) {
+	s.botCommands = append(s.botCommands, NewBotCommand(usage, description, handler, options...))
 }
 
-// Listen receives events from Slack and each is handled as needed
-func (s *Slacker) Listen() error {
-	s.prependHelpHandle()
+// Listen receives events from Slack and each is handled as needed.
+// Handler contexts are derived from the given context, carrying its deadline, cancellation and values.
+// Listen returns once the context is done or the bot is closed, canceling the contexts of running handlers.
+// Otherwise it fails with an error wrapping ErrInvalidAuth when the token is refused,
+// or ErrDisconnected when the connection could not be established again
+func (s *Slacker) Listen(ctx context.Context) error {
+	err := s.ValidateToken()
+	if err != nil {
+		return err
+	}
 
+	s.start()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	atomic.StoreInt32(&s.rtmStarted, 1)
 	go s.RTM.ManageConnection()
 
-	for msg := range s.RTM.IncomingEvents {
-		switch event := msg.Data.(type) {
-		case *slack.ConnectedEvent:
-			if s.initHandler == nil {
-				continue
-			}
-			go s.initHandler()
+	for {
+		select {
+		case <-ctx.Done():
+			return s.Close()
 
-		case *slack.MessageEvent:
-			/*if s.isFromBot(event) {
-				fmt.Printf("dropping from bot: %#v\n", event)
-				continue
-			}*/
+		case <-s.done:
+			return nil
 
-			if !s.isBotMentioned(event) && !s.isDirectMessage(event) {
-				fmt.Printf("dropping not mentioned or not direct message: %#v\n", event)
-				continue
-			}
-			fmt.Printf("handling message: %#v\n", event)
-			go s.handleMessage(event)
+		case msg := <-s.RTM.IncomingEvents:
+			switch event := msg.Data.(type) {
+			case *slack.ConnectingEvent:
+				s.trackConnecting(event)
 
-		case *slack.RTMError:
-			if s.errorHandler == nil {
-				continue
-			}
-			go s.errorHandler(event.Error())
+				if s.defaultEventHandler == nil {
+					continue
+				}
+				go s.defaultEventHandler(event)
+
+			case *slack.ConnectionErrorEvent:
+				attempts := s.trackConnectionError(event)
+				if s.defaults.MaxReconnectAttempts > 0 && attempts >= s.defaults.MaxReconnectAttempts {
+					s.Close()
+					return fmt.Errorf(reconnectFailedFormat, ErrDisconnected, attempts, event.ErrorObj)
+				}
+				s.delayReconnect(event, attempts)
+
+				if s.defaultEventHandler == nil {
+					continue
+				}
+				go s.defaultEventHandler(event)
+
+			case *slack.ConnectedEvent:
+				atomic.AddInt32(&s.connections, 1)
+				s.setConnected(true)
+				s.goHandle(s.flushQueue)
+				s.goHandle(s.sendPresenceSubscriptions)
+				s.trackConnected(event)
+
+				if s.initHandler == nil {
+					continue
+				}
+				go s.initHandler()
+
+			case *slack.DisconnectedEvent:
+				s.setConnected(false)
+				s.trackDisconnected(event)
+
+				if s.defaultEventHandler == nil {
+					continue
+				}
+				go s.defaultEventHandler(event)
+
+			case *slack.MessageEvent:
+				response := s.newResponse(event, s.newConnectionResponse(event.Channel))
+				s.goHandle(func() {
+					s.handleMessageEvent(withTransport(ctx, &transportDetails{transport: RTMTransport}), event, response)
+				})
+
+			case *slack.FileSharedEvent:
+				s.goHandle(func() {
+					s.handleFileShared(&FileSharedEvent{FileID: event.File.ID, EventTimestamp: event.EventTimestamp})
+				})
+
+			case *slack.PresenceChangeEvent:
+				s.goHandle(func() {
+					if s.handlePresenceChange(event) || s.defaultEventHandler == nil {
+						return
+					}
+					s.defaultEventHandler(event)
+				})
+
+			case *slack.UserChangeEvent:
+				user := event.User
+				s.goHandle(func() {
+					s.handleUserChange(&user)
+				})
 
-		case *slack.InvalidAuthEvent:
-			return errors.New(invalidToken)
+			case *slack.ReactionAddedEvent:
+				s.goHandle(func() {
+					if s.handleReactionAdded(event) || s.defaultEventHandler == nil {
+						return
+					}
+					s.defaultEventHandler(event)
+				})
 
-		default:
-			if s.defaultEventHandler == nil {
-				continue
+			case *slack.ReactionRemovedEvent:
+				s.goHandle(func() {
+					if s.handlePollReactionRemoved(event) || s.defaultEventHandler == nil {
+						return
+					}
+					s.defaultEventHandler(event)
+				})
+
+			case *slack.LatencyReport:
+				s.trackLatency(event)
+
+				if s.defaultEventHandler == nil {
+					continue
+				}
+				go s.defaultEventHandler(event)
+
+			case *slack.RTMError:
+				atomic.AddInt32(&s.errors, 1)
+				if s.errorHandler == nil {
+					continue
+				}
+				go s.errorHandler(event.Error())
+
+			case *slack.OutgoingErrorEvent:
+				// Messages the connection failed to send are retried through the Web API
+				message := event.Message
+				if message.Type != messageEventType {
+					continue
+				}
+
+				s.goHandle(func() {
+					s.postMessage(message.Channel, message.Text)
+				})
+
+			case *slack.MessageTooLongEvent:
+				if s.sendFailureHandler == nil {
+					continue
+				}
+				go s.sendFailureHandler(event.Message.Channel, event.Message.Text, event)
+
+			case *slack.InvalidAuthEvent:
+				return ErrInvalidAuth
+
+			default:
+				if s.defaultEventHandler == nil {
+					continue
+				}
+				go s.defaultEventHandler(event)
 			}
-			go s.defaultEventHandler(event)
 		}
 	}
-	return nil
 }
 
 func (s *Slacker) sendMessage(text string, channel string) {
-	s.RTM.SendMessage(s.RTM.NewOutgoingMessage(text, channel))
+	s.sender.SendMessage(s.sender.NewOutgoingMessage(text, channel))
 }
 
+// isFromBot determines whether the message was sent by a bot, a workflow or Slackbot rather than a person
 func (s *Slacker) isFromBot(event *slack.MessageEvent) bool {
-	info := s.RTM.GetInfo()
-	return len(event.User) == 0 || event.User == slackBotUser || event.User == info.User.ID || len(event.BotID) > 0
+	return len(event.User) == 0 || event.User == slackBotUser || len(event.BotID) > 0 || event.SubType == botMessageSubType
+}
+
+// acceptsMessage determines whether the command can be run by the message, depending on whether bots are allowed
+func (s *Slacker) acceptsMessage(command *BotCommand, event *slack.MessageEvent) bool {
+	if !s.isFromBot(event) {
+		return true
+	}
+
+	if command.botMessages != nil {
+		return *command.botMessages
+	}
+	return s.defaults.BotMessages
 }
 
 func (s *Slacker) isBotMentioned(event *slack.MessageEvent) bool {
-	info := s.RTM.GetInfo()
-	return strings.Contains(event.Text, fmt.Sprintf(userMentionFormat, info.User.ID)) || strings.Contains(event.Attachments[0].Pretext, fmt.Sprintf(userMentionFormat, info.User.ID))
+	mention := format.Mention(s.botUserID())
+	return strings.Contains(event.Text, mention) || strings.Contains(attachmentPretext(event), mention)
+}
+
+// botUserID returns the bot's user ID, asking the Web API when there is no RTM connection
+func (s *Slacker) botUserID() string {
+	info := s.info.GetInfo()
+	if info != nil {
+		return info.User.ID
+	}
+
+	s.authOnce.Do(func() {
+		response, err := s.authenticator.AuthTest()
+		if err != nil {
+			s.reportError(err)
+			return
+		}
+		s.userID = response.UserID
+	})
+	return s.userID
+}
+
+// isAdmin determines whether the user is one of the bot's admins
+func (s *Slacker) isAdmin(userID string) bool {
+	for _, admin := range s.defaults.Admins {
+		if admin == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Slacker) handleHandlerError(request *Request, err error) {
+	atomic.AddInt32(&s.errors, 1)
+	if s.handlerErrorHandler == nil {
+		return
+	}
+	s.handlerErrorHandler(request.Context, request, err)
+}
+
+// isAddressed determines whether the message addresses the bot, by mentioning it, in direct messages or as a slash command
+func (s *Slacker) isAddressed(ctx context.Context, event *slack.MessageEvent) bool {
+	return s.isBotMentioned(event) || s.isDirectMessage(event) || transportFrom(ctx).slashCommand != nil
+}
+
+// runFallback calls the fallback handler, a panicking one claiming the message
+func (s *Slacker) runFallback(fallbackHandler func(request *Request, response ResponseWriter) bool, request *Request, response ResponseWriter) (claimed bool) {
+	claimed = true
+	defer s.recoverHandler(request, response)
+	return fallbackHandler(request, response)
+}
+
+// recoverHandler recovers from a panicking handler, telling the user and reporting the panic as a handler error.
+// It must be deferred
+func (s *Slacker) recoverHandler(request *Request, response ResponseWriter) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	response.ReportError(errors.New(commandPanicked))
+	s.handleHandlerError(request, fmt.Errorf(panicFormat, recovered))
+}
+
+func (s *Slacker) reportError(err error) {
+	atomic.AddInt32(&s.errors, 1)
+	if s.errorHandler == nil {
+		return
+	}
+	s.errorHandler(err.Error())
 }
 
 func (s *Slacker) isDirectMessage(event *slack.MessageEvent) bool {
 	return strings.HasPrefix(event.Channel, directChannelMarker)
 }
 
-func (s *Slacker) handleMessage(event *slack.MessageEvent) {
-	response := NewResponse(event.Channel, s.RTM)
-	ctx := context.Background()
+// newResponse applies the custom response constructor, if any, to the framework's ResponseWriter
+func (s *Slacker) newResponse(event *slack.MessageEvent, response ResponseWriter) ResponseWriter {
+	if s.responseConstructor == nil {
+		return response
+	}
+	return s.responseConstructor(event, response)
+}
+
+// newRequest creates a request giving handlers access to the Web API client
+func (s *Slacker) newRequest(ctx context.Context, event *slack.MessageEvent, properties *proper.Properties) *Request {
+	request := NewRequest(ctx, event, properties)
+	request.Client = s.APIClient()
+	request.slacker = s
+
+	details := transportFrom(ctx)
+	request.Transport = details.transport
+	request.SlashCommand = details.slashCommand
+	request.files = details.files
+	request.enterpriseID = details.enterpriseID
+	request.userTeamID = details.userTeamID
+	request.store = s.sessionStore()
+	request.sessionTTL = s.defaults.SessionTTL
+	return request
+}
+
+// newConnectionResponse creates the response to a message received over the connection, replying through
+// the Web API unless replies over the connection are enabled
+func (s *Slacker) newConnectionResponse(channel string) ResponseWriter {
+	if s.defaults.RTMReplies {
+		return newRTMResponse(channel, s)
+	}
+
+	response := newAPIResponse(channel, s)
+	response.sender = &queueingSender{slacker: s}
+	return response
+}
+
+func (s *Slacker) handleMessageEvent(ctx context.Context, event *slack.MessageEvent, response ResponseWriter) {
+	switch event.SubType {
+	case messageChanged:
+		s.handleMessageEdited(ctx, event, response)
+		return
+
+	case messageDeleted:
+		if s.messageDeletedHandler != nil {
+			s.messageDeletedHandler(event.Channel, event.DeletedTimestamp)
+		}
+		return
+	}
+
+	// The bot's own messages are never handled, to avoid loops
+	if len(event.User) > 0 && event.User == s.botUserID() {
+		return
+	}
+
+	if s.isDirectMessage(event) && (s.collectAnswer(event) || s.collectFormAnswer(event)) {
+		return
+	}
+
+	s.triggerKeywords(ctx, event, response)
 
+	if !s.isBotMentioned(event) && !s.isDirectMessage(event) && !s.isAmbient(event) {
+		return
+	}
+	s.handleMessage(ctx, event, response)
+}
+
+func (s *Slacker) handleMessageEdited(ctx context.Context, event *slack.MessageEvent, response ResponseWriter) {
+	// Unfurling links also changes a message, only actual edits are considered
+	if event.SubMessage == nil || event.SubMessage.Edited == nil {
+		return
+	}
+
+	edited := &slack.MessageEvent{Msg: *event.SubMessage}
+	edited.Channel = event.Channel
+	edited.Team = event.Team
+
+	if s.messageEditedHandler != nil {
+		s.messageEditedHandler(s.newRequest(ctx, edited, &proper.Properties{}), response)
+	}
+
+	if !s.isRetryableEdit(edited) {
+		return
+	}
+	s.handleMessageEvent(ctx, edited, response)
+}
+
+// handleMessage executes the first matching command, returning the error it failed with, if any
+func (s *Slacker) handleMessage(ctx context.Context, event *slack.MessageEvent, response ResponseWriter) error {
+	requestID := newRequestID()
+	ctx = withRequestID(ctx, requestID)
+
+	s.recordHistory(ctx, event)
+	s.publish(&BusEvent{Name: MessageReceivedEvent, Event: event, Text: event.Text, RequestID: requestID})
+
+	var usageCommand *BotCommand
+	var usageErr error
 	for _, cmd := range s.botCommands {
-		textParameters, isTextMatch := cmd.Match(event.Text)
-		attachmentParameters, isAttachmentMatch := cmd.Match(event.Attachments[0].Pretext)
-		if isTextMatch {
-			cmd.Execute(NewRequest(ctx, event, textParameters), response)
-		} else if isAttachmentMatch {
-			cmd.Execute(NewRequest(ctx, event, attachmentParameters), response)
-		} else {
+		if !s.acceptsMessage(cmd, event) || !s.isCommandAllowed(cmd, event) {
 			continue
 		}
 
-		return
+		textParameters, isTextMatch, err := s.matchCommand(cmd, event.Text)
+		attachmentParameters, isAttachmentMatch, _ := s.matchCommand(cmd, attachmentPretext(event))
+		if !isTextMatch && !isAttachmentMatch {
+			// Another command may still match, the first usage error is only replied otherwise
+			if err != nil && usageCommand == nil {
+				usageCommand = cmd
+				usageErr = err
+			}
+			continue
+		}
 
+		s.publish(&BusEvent{Name: CommandMatchedEvent, Event: event, Command: cmd.usage, Text: event.Text, RequestID: requestID})
+
+		message := s.maintenanceMessage(cmd)
+		if len(message) > 0 {
+			response.Reply(message)
+			return nil
+		}
+
+		if cmd.internalOnly && s.isExternalUser(event, s.userTeamID(event, transportFrom(ctx).userTeamID)) {
+			response.Reply(s.defaults.ExternalUserRefusal)
+			return nil
+		}
+
+		if s.isFlooding(ctx, cmd, event) {
+			return nil
+		}
+
+		parameters := textParameters
+		if !isTextMatch {
+			parameters = attachmentParameters
+		}
+
+		err = cmd.validate(parameters)
+		if err != nil {
+			response.Reply(cmd.usageError(err))
+			return nil
+		}
+
+		warning := cmd.deprecationWarning()
+		if len(warning) > 0 {
+			response.Reply(warning)
+		}
+		return s.executeCommand(cmd, s.newRequest(ctx, event, parameters), response)
+	}
+
+	s.rememberUnmatched(event)
+
+	// In ambient channels, only commands answer the messages that do not address the bot
+	if s.isAmbient(event) && !s.isAddressed(ctx, event) {
+		return nil
+	}
+
+	if usageCommand != nil {
+		response.Reply(usageCommand.usageError(usageErr))
+		return nil
+	}
+
+	if s.isFromBot(event) && !s.defaults.BotMessages {
+		return nil
+	}
+
+	request := s.newRequest(ctx, event, &proper.Properties{})
+	for _, fallbackHandler := range s.fallbackHandlers {
+		if s.runFallback(fallbackHandler, request, response) {
+			return nil
+		}
 	}
 
 	if s.defaultMessageHandler != nil {
-		s.defaultMessageHandler(NewRequest(ctx, event, &proper.Properties{}), response)
+		s.defaultMessageHandler(request, response)
 	}
+	return nil
 }
 
+// defaultHelp details the commands named after the help trigger, as in "help deploy", replying that none matches
+// when there is no such command, or else lists all the commands the user can use, in a thread when there are too many of them
 func (s *Slacker) defaultHelp(request *Request, response ResponseWriter) {
-	helpMessage := empty
-	for _, command := range s.botCommands {
-		tokens := command.Tokenize()
-		for _, token := range tokens {
-			if token.IsParameter {
-				helpMessage += fmt.Sprintf(codeMessageFormat, token.Word) + space
-			} else {
-				helpMessage += fmt.Sprintf(boldMessageFormat, token.Word) + space
+	topic := strings.Fields(request.Param(helpTopicParam))
+	if len(topic) > 0 {
+		details := empty
+		for _, command := range s.allowedCommands(request.Event) {
+			if command.isNamed(topic, s.defaults.CaseSensitive) {
+				details += command.helpDetails() + newLine
 			}
 		}
-		helpMessage += dash + space + fmt.Sprintf(italicMessageFormat, command.description) + newLine
+
+		if len(details) == 0 {
+			details = fmt.Sprintf(unknownHelpTopic, format.Code(strings.Join(topic, space)))
+		}
+		response.Reply(details)
+		return
 	}
-	response.Reply(helpMessage)
+
+	commands := s.allowedCommands(request.Event)
+	if s.defaults.HelpThreadThreshold > 0 && len(commands) > s.defaults.HelpThreadThreshold && s.replyHelpThread(commands, response) {
+		return
+	}
+	response.Reply(helpMessage(commands))
+}
+
+// deliverHelp calls the help handler with the response the help is delivered through
+func (s *Slacker) deliverHelp(request *Request, response ResponseWriter) {
+	s.helpHandler(request, s.helpResponse(request, response))
+}
+
+// start prepares the bot to handle events, once whatever the transport
+func (s *Slacker) start() {
+	s.prependHelpHandle()
+	s.startOnce.Do(func() {
+		s.resumeDeletions()
+		go s.flushQueuePeriodically()
+	})
 }
 
 func (s *Slacker) prependHelpHandle() {
-	if s.helpHandler == nil {
-		s.helpHandler = s.defaultHelp
+	s.helpOnce.Do(func() {
+		if s.defaults.DisableHelp {
+			return
+		}
+
+		if s.helpHandler == nil {
+			s.helpHandler = s.defaultHelp
+		}
+
+		// Only the first trigger is listed in the help, the others being alternate words for it
+		helpCommands := make([]*BotCommand, 0, len(s.defaults.HelpTriggers))
+		for i, trigger := range s.defaults.HelpTriggers {
+			usage := trigger + space + optionalPrefix + helpTopicParam + variadicSuffix + optionalSuffix
+			command := NewBotCommand(usage, helpCommand, s.deliverHelp)
+			command.hidden = i > 0
+			helpCommands = append(helpCommands, command)
+		}
+		s.botCommands = append(helpCommands, s.botCommands...)
+	})
+}
+
+// attachmentPretext returns the pretext of the message's first attachment, if any
+func attachmentPretext(event *slack.MessageEvent) string {
+	if len(event.Attachments) == 0 {
+		return empty
 	}
-	s.botCommands = append([]*BotCommand{NewBotCommand(helpCommand, helpCommand, s.helpHandler)}, s.botCommands...)
+	return event.Attachments[0].Pretext
 }
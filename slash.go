@@ -0,0 +1,104 @@
+package slacker
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	slashCommandPrefix = "/"
+	commandField       = "command"
+	textField          = "text"
+	userIDField        = "user_id"
+	userNameField      = "user_name"
+	channelIDField     = "channel_id"
+	teamIDField        = "team_id"
+	responseURLField   = "response_url"
+	triggerIDField     = "trigger_id"
+)
+
+// SlashCommand contains the details of a slash command
+type SlashCommand struct {
+	Command     string
+	Text        string
+	UserID      string
+	UserName    string
+	ChannelID   string
+	TeamID      string
+	ResponseURL string
+	TriggerID   string
+}
+
+// SlashCommandsHandler returns an http.Handler serving the request URL of the bot's slash commands.
+// Slash commands are acknowledged immediately, well within Slack's three seconds, and handled asynchronously,
+// replying through their response URL.
+// A slash command is matched against the commands as a message made of its name without the slash
+// followed by its text, so "/deploy staging" runs the "deploy <env>" command.
+// Handler contexts are derived from the given context, and canceled once the bot is closed
+func (s *Slacker) SlashCommandsHandler(ctx context.Context) http.Handler {
+	s.start()
+
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		<-s.done
+		cancel()
+	}()
+
+	return s.VerifySignature(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if s.isClosed() {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		s.serveSlashCommand(ctx, writer, request)
+	}))
+}
+
+func (s *Slacker) serveSlashCommand(ctx context.Context, writer http.ResponseWriter, request *http.Request) {
+	err := request.ParseForm()
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	command := &SlashCommand{
+		Command:     request.PostForm.Get(commandField),
+		Text:        request.PostForm.Get(textField),
+		UserID:      request.PostForm.Get(userIDField),
+		UserName:    request.PostForm.Get(userNameField),
+		ChannelID:   request.PostForm.Get(channelIDField),
+		TeamID:      request.PostForm.Get(teamIDField),
+		ResponseURL: request.PostForm.Get(responseURLField),
+		TriggerID:   request.PostForm.Get(triggerIDField),
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	s.goHandle(func() {
+		s.handleSlashCommand(ctx, command)
+	})
+}
+
+func (s *Slacker) handleSlashCommand(ctx context.Context, command *SlashCommand) {
+	_, err := s.apiToken()
+	if err != nil {
+		s.reportError(err)
+		return
+	}
+
+	event := &slack.MessageEvent{}
+	event.Type = messageEventType
+	event.Text = strings.TrimSpace(strings.TrimPrefix(command.Command, slashCommandPrefix) + space + command.Text)
+	event.User = command.UserID
+	event.Channel = command.ChannelID
+	event.Team = command.TeamID
+
+	var response ResponseWriter = newAPIResponse(event.Channel, s)
+	if len(command.ResponseURL) > 0 {
+		response = newResponseURLResponse(command.ResponseURL, command.ChannelID, s)
+	}
+
+	ctx = withTransport(ctx, &transportDetails{transport: SlashCommandTransport, slashCommand: command})
+	s.handleMessage(ctx, event, s.newResponse(event, response))
+}
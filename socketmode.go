@@ -0,0 +1,130 @@
+package slacker
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/socketmode"
+)
+
+// ListenSocketMode receives events, slash commands and interactions over a Socket Mode connection opened
+// with the app-level token (xapp-), for bots that cannot expose request URLs to Slack.
+// They are handled as they would be when posted to the EventsHandler, SlashCommandsHandler and InteractionsHandler.
+// Handler contexts are derived from the given context, carrying its deadline, cancellation and values.
+// ListenSocketMode returns once the context is done or the bot is closed, canceling the contexts of running handlers.
+// When the connection fails, only it is closed and its error returned, so the bot can listen again
+func (s *Slacker) ListenSocketMode(ctx context.Context, appToken string) error {
+	err := s.ValidateToken()
+	if err != nil {
+		return err
+	}
+
+	token, err := s.apiToken()
+	if err != nil {
+		return err
+	}
+
+	s.start()
+	atomic.StoreInt32(&s.interactive, 1)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	api := slack.New(token, slack.OptionAppLevelToken(appToken), slack.OptionHTTPClient(s.defaults.HTTPClient))
+	client := socketmode.New(api, socketmode.OptionDialer(newWebsocketDialer(s.defaults.HTTPClient)))
+	failed := make(chan error, 1)
+	go func() {
+		failed <- client.RunContext(ctx)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return s.Close()
+
+		case <-s.done:
+			return nil
+
+		case err := <-failed:
+			s.setConnected(false)
+			return err
+
+		case event := <-client.Events:
+			switch event.Type {
+			case socketmode.EventTypeConnected:
+				atomic.AddInt32(&s.connections, 1)
+				s.setConnected(true)
+				s.goHandle(s.flushQueue)
+
+				if s.initHandler == nil {
+					continue
+				}
+				go s.initHandler()
+
+			case socketmode.EventTypeConnectionError, socketmode.EventTypeDisconnect:
+				s.setConnected(false)
+
+			case socketmode.EventTypeInvalidAuth:
+				return ErrInvalidAuth
+
+			case socketmode.EventTypeEventsAPI:
+				client.Ack(*event.Request)
+
+				payload := &eventsPayload{}
+				err := json.Unmarshal(event.Request.Payload, payload)
+				if err != nil {
+					s.reportError(err)
+					continue
+				}
+
+				// Events that were not acknowledged in time are delivered again, a redelivery is only acknowledged
+				if s.eventCache.Seen(payload.EventID) && event.Request.RetryAttempt > 0 {
+					continue
+				}
+				s.goHandle(func() {
+					s.handleCallbackEvent(ctx, payload)
+				})
+
+			case socketmode.EventTypeSlashCommand:
+				client.Ack(*event.Request)
+
+				data, ok := event.Data.(slack.SlashCommand)
+				if !ok {
+					continue
+				}
+
+				command := &SlashCommand{
+					Command:     data.Command,
+					Text:        data.Text,
+					UserID:      data.UserID,
+					UserName:    data.UserName,
+					ChannelID:   data.ChannelID,
+					TeamID:      data.TeamID,
+					ResponseURL: data.ResponseURL,
+					TriggerID:   data.TriggerID,
+				}
+				s.goHandle(func() {
+					s.handleSlashCommand(ctx, command)
+				})
+
+			case socketmode.EventTypeInteractive:
+				payload := &interaction{}
+				err := json.Unmarshal(event.Request.Payload, payload)
+				if err != nil {
+					client.Ack(*event.Request)
+					s.reportError(err)
+					continue
+				}
+
+				acknowledgment := s.acceptInteraction(payload)
+				if acknowledgment == nil {
+					client.Ack(*event.Request)
+					continue
+				}
+				client.Ack(*event.Request, acknowledgment)
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package slacker
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/shomali11/slacker/format"
+)
+
+const (
+	statsCommand       = "stats"
+	statsDescription   = "Show how the bot and its commands are used"
+	statsUptimeFormat  = "*Uptime:* %s"
+	statsConnFormat    = "*Connections:* %d"
+	statsErrorsFormat  = "*Errors:* %d"
+	statsCommandFormat = "%s - %d uses, %d failures"
+	statsLastUsedFmt   = ", last used %s"
+	statsTimeLayout    = "2006-01-02 15:04"
+	statsDeprecated    = " (deprecated)"
+)
+
+// CommandStats contains the usage of a command since the bot started
+type CommandStats struct {
+	Usage       string
+	Deprecated  bool
+	Invocations int
+	Failures    int
+	LastUsed    time.Time
+}
+
+// commandStats tracks the usage of every command
+type commandStats struct {
+	mutex    sync.Mutex
+	commands map[string]*CommandStats
+}
+
+// record counts an invocation of the command, and whether it failed
+func (c *commandStats) record(usage string, failed bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.commands == nil {
+		c.commands = make(map[string]*CommandStats)
+	}
+
+	stats, ok := c.commands[usage]
+	if !ok {
+		stats = &CommandStats{Usage: usage}
+		c.commands[usage] = stats
+	}
+
+	stats.Invocations++
+	stats.LastUsed = time.Now()
+	if failed {
+		stats.Failures++
+	}
+}
+
+// StatsCommand registers an admin command showing the bot's uptime, connection count, error total
+// and the usage of every command, most used first
+func (s *Slacker) StatsCommand() {
+	s.Command(statsCommand, statsDescription, func(request *Request, response ResponseWriter) {
+		message := fmt.Sprintf(statsUptimeFormat, time.Since(s.startedAt).Round(time.Second)) + newLine
+		message += fmt.Sprintf(statsConnFormat, atomic.LoadInt32(&s.connections)) + newLine
+		message += fmt.Sprintf(statsErrorsFormat, atomic.LoadInt32(&s.errors)) + newLine
+
+		stats := s.Stats()
+		sort.SliceStable(stats, func(i, j int) bool {
+			return stats[i].Invocations > stats[j].Invocations
+		})
+
+		for _, commandStats := range stats {
+			message += fmt.Sprintf(statsCommandFormat, format.Code(commandStats.Usage), commandStats.Invocations, commandStats.Failures)
+			if !commandStats.LastUsed.IsZero() {
+				message += fmt.Sprintf(statsLastUsedFmt, commandStats.LastUsed.Format(statsTimeLayout))
+			}
+
+			if commandStats.Deprecated {
+				message += statsDeprecated
+			}
+			message += newLine
+		}
+		response.Reply(message)
+	}, WithAdminOnly())
+}
+
+// Stats returns the usage of every command, including those never used, in the order they were defined.
+// Hidden commands, such as the alternate help triggers, are left out
+func (s *Slacker) Stats() []*CommandStats {
+	s.stats.mutex.Lock()
+	defer s.stats.mutex.Unlock()
+
+	stats := make([]*CommandStats, 0, len(s.botCommands))
+	for _, command := range s.botCommands {
+		if command.hidden {
+			continue
+		}
+
+		commandStats := &CommandStats{Usage: command.usage}
+		recorded, ok := s.stats.commands[command.usage]
+		if ok {
+			*commandStats = *recorded
+		}
+
+		commandStats.Deprecated = command.deprecated
+		stats = append(stats, commandStats)
+	}
+	return stats
+}
@@ -0,0 +1,141 @@
+package slacker
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMemoryStoreEntries = 10000
+	memoryStoreSweepInterval  = time.Minute
+)
+
+// Store persists the bot's state, such as queued messages, so it can survive restarts
+type Store interface {
+	// Get returns the value stored under the key and whether it was found
+	Get(key string) ([]byte, bool, error)
+
+	// Set stores the value under the key, expiring it after the TTL unless the TTL is zero
+	Set(key string, value []byte, ttl time.Duration) error
+
+	// Delete removes the key
+	Delete(key string) error
+}
+
+// defaultStore keeps the state in memory when no store is configured, shared by the bots and requests of the process
+var defaultStore = NewMemoryStore(defaultMemoryStoreEntries)
+
+// NewMemoryStore creates a store keeping values in memory. Once it holds maxEntries values,
+// storing another evicts the least recently used one. A maxEntries of zero means no limit
+func NewMemoryStore(maxEntries int) *MemoryStore {
+	return &MemoryStore{maxEntries: maxEntries, entries: make(map[string]*list.Element), order: list.New()}
+}
+
+// MemoryStore keeps values in memory until they expire or are evicted.
+// Its state is lost on restart and is not shared between replicas
+type MemoryStore struct {
+	mutex      sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List
+	lastSweep  time.Time
+}
+
+// memoryEntry is a value and the time it expires, if any
+type memoryEntry struct {
+	key    string
+	value  []byte
+	expiry time.Time
+}
+
+// Get returns the value stored under the key and whether it was found
+func (m *MemoryStore) Get(key string) ([]byte, bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	entry := element.Value.(*memoryEntry)
+	if entry.isExpired(time.Now()) {
+		m.remove(element)
+		return nil, false, nil
+	}
+
+	m.order.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+// Set stores the value under the key, expiring it after the TTL unless the TTL is zero
+func (m *MemoryStore) Set(key string, value []byte, ttl time.Duration) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	entry := &memoryEntry{key: key, value: value}
+	if ttl > 0 {
+		entry.expiry = time.Now().Add(ttl)
+	}
+
+	element, ok := m.entries[key]
+	if ok {
+		element.Value = entry
+		m.order.MoveToFront(element)
+		return nil
+	}
+
+	m.entries[key] = m.order.PushFront(entry)
+	m.evict()
+	return nil
+}
+
+// Delete removes the key
+func (m *MemoryStore) Delete(key string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	element, ok := m.entries[key]
+	if ok {
+		m.remove(element)
+	}
+	return nil
+}
+
+// Len returns the number of values held, including expired ones not yet removed
+func (m *MemoryStore) Len() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.order.Len()
+}
+
+// evict removes the least recently used values while over capacity.
+// Expired values are swept at most once per interval to keep writes cheap
+func (m *MemoryStore) evict() {
+	now := time.Now()
+	if now.Sub(m.lastSweep) >= memoryStoreSweepInterval {
+		m.lastSweep = now
+		for element := m.order.Back(); element != nil; {
+			previous := element.Prev()
+			if element.Value.(*memoryEntry).isExpired(now) {
+				m.remove(element)
+			}
+			element = previous
+		}
+	}
+
+	for m.maxEntries > 0 && m.order.Len() > m.maxEntries {
+		m.remove(m.order.Back())
+	}
+}
+
+func (m *MemoryStore) remove(element *list.Element) {
+	m.order.Remove(element)
+	delete(m.entries, element.Value.(*memoryEntry).key)
+}
+
+func (e *memoryEntry) isExpired(now time.Time) bool {
+	return !e.expiry.IsZero() && now.After(e.expiry)
+}
@@ -0,0 +1,81 @@
+package slacker
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/shomali11/slacker/format"
+)
+
+const (
+	maxTableMessageLength = 3000
+	maxTableSnippetRows   = 500
+	tableColumnSeparator  = "  "
+	tableSnippetName      = "table.txt"
+	tableSnippetType      = "text"
+	tableCSVName          = "table.csv"
+	tableCSVType          = "csv"
+)
+
+// renderTable aligns the columns of the table in plain text, underlining the headers
+func renderTable(headers []string, rows [][]string) string {
+	widths := make([]int, len(headers))
+	for _, row := range append([][]string{headers}, rows...) {
+		for i, cell := range row {
+			if i < len(widths) && utf8.RuneCountInString(cell) > widths[i] {
+				widths[i] = utf8.RuneCountInString(cell)
+			}
+		}
+	}
+
+	separators := make([]string, len(headers))
+	for i, width := range widths {
+		separators[i] = strings.Repeat(dash, width)
+	}
+
+	lines := make([]string, 0, len(rows)+2)
+	for _, row := range append([][]string{headers, separators}, rows...) {
+		cells := make([]string, len(widths))
+		for i, width := range widths {
+			cell := empty
+			if i < len(row) {
+				cell = row[i]
+			}
+			cells[i] = cell + strings.Repeat(space, width-utf8.RuneCountInString(cell))
+		}
+		lines = append(lines, strings.TrimRight(strings.Join(cells, tableColumnSeparator), space))
+	}
+	return strings.Join(lines, newLine)
+}
+
+// renderCSV encodes the table as CSV
+func renderCSV(headers []string, rows [][]string) (string, error) {
+	buffer := &bytes.Buffer{}
+	writer := csv.NewWriter(buffer)
+	err := writer.WriteAll(append([][]string{headers}, rows...))
+	if err != nil {
+		return empty, err
+	}
+	return buffer.String(), nil
+}
+
+// postTable posts the table in a code block when it fits in a message, or else uploads it
+// as a text snippet, or as a CSV file when it has too many rows to read in a snippet
+func (s *Slacker) postTable(channel string, headers []string, rows [][]string, defaults *ReplyDefaults) error {
+	table := renderTable(headers, rows)
+	if len(table) <= maxTableMessageLength {
+		return s.postMessage(channel, format.CodeBlock(table), defaults.msgOptions()...)
+	}
+
+	if len(rows) <= maxTableSnippetRows {
+		return s.uploadFile(channel, tableSnippetName, tableSnippetType, strings.NewReader(table), empty, defaults.ThreadTimestamp)
+	}
+
+	content, err := renderCSV(headers, rows)
+	if err != nil {
+		return err
+	}
+	return s.uploadFile(channel, tableCSVName, tableCSVType, strings.NewReader(content), empty, defaults.ThreadTimestamp)
+}
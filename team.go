@@ -0,0 +1,80 @@
+package slacker
+
+import (
+	"net/url"
+	"time"
+)
+
+const (
+	teamInfoMethod = "team.info"
+	teamField      = "team"
+	teamCacheTTL   = time.Hour
+)
+
+// Team contains the metadata of a workspace, along with the Enterprise Grid organization it belongs to, if any
+type Team struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Domain         string `json:"domain"`
+	EmailDomain    string `json:"email_domain"`
+	EnterpriseID   string `json:"enterprise_id"`
+	EnterpriseName string `json:"enterprise_name"`
+	url            string
+	expiry         time.Time
+}
+
+// URL returns the address of the workspace as reported by Slack, which also holds for Enterprise Grid workspaces.
+// Links to messages are built with Permalink instead, as they depend on the thread
+func (t *Team) URL() string {
+	return t.url
+}
+
+// teamInfo decodes the team along with its address
+type teamInfo struct {
+	*Team
+	URL string `json:"url"`
+}
+
+// teamInfoResponse is the team.info response
+type teamInfoResponse struct {
+	Team *teamInfo `json:"team"`
+}
+
+// TeamInfo returns the metadata of the workspace, or of the bot's own workspace when the ID is empty.
+// Workspaces are cached for an hour
+func (s *Slacker) TeamInfo(teamID string) (*Team, error) {
+	s.teamsMutex.Lock()
+	team, ok := s.teams[teamID]
+	s.teamsMutex.Unlock()
+	if ok && time.Now().Before(team.expiry) {
+		return team, nil
+	}
+
+	values := url.Values{}
+	if len(teamID) > 0 {
+		values.Set(teamField, teamID)
+	}
+
+	team = &Team{}
+	response := &teamInfoResponse{Team: &teamInfo{Team: team}}
+
+	err := s.queryAPI(teamInfoMethod, values, response)
+	if err != nil {
+		return nil, err
+	}
+
+	team.url = response.Team.URL
+	team.expiry = time.Now().Add(teamCacheTTL)
+	s.teamsMutex.Lock()
+	s.teams[teamID] = team
+	s.teamsMutex.Unlock()
+	return team, nil
+}
+
+// Team returns the metadata of the workspace the message was sent in, such as its domain and Enterprise Grid organization
+func (r *Request) Team() (*Team, error) {
+	if r.slacker == nil {
+		return nil, ErrNoBotClient
+	}
+	return r.slacker.TeamInfo(r.Event.Team)
+}
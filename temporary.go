@@ -0,0 +1,136 @@
+package slacker
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	chatDeleteMethod = "chat.delete"
+	temporaryKey     = "slacker:temporary"
+	cannotDelete     = "the reply cannot be deleted, as it is not posted through the Web API"
+)
+
+// temporaryMessage is a posted message waiting to be deleted
+type temporaryMessage struct {
+	Channel   string    `json:"channel"`
+	Timestamp string    `json:"timestamp"`
+	DeleteAt  time.Time `json:"delete_at"`
+}
+
+// postTemporary posts a message through the Web API and deletes it once the TTL elapses.
+// Temporary messages are never queued, as a message delivered later would never be deleted.
+// When a store is configured, pending deletions are kept in it and resumed after a restart
+func (s *Slacker) postTemporary(channel string, text string, ttl time.Duration, options ...slack.MsgOption) error {
+	timestamp, err := s.postWithRetries(channel, text, options...)
+	if err != nil {
+		s.sendFailed(channel, text, err)
+		return err
+	}
+
+	// Dry runs post nothing to delete
+	if len(timestamp) == 0 {
+		return nil
+	}
+
+	message := &temporaryMessage{Channel: channel, Timestamp: timestamp, DeleteAt: time.Now().Add(ttl)}
+	if s.defaults.Store != nil {
+		err = s.updateTemporaryMessages(func(messages []*temporaryMessage) []*temporaryMessage {
+			return append(messages, message)
+		})
+		if err != nil {
+			s.reportError(err)
+		}
+	}
+
+	s.scheduleDeletion(message)
+	return nil
+}
+
+// scheduleDeletion deletes the message once its time comes, forgetting it in the store
+func (s *Slacker) scheduleDeletion(message *temporaryMessage) {
+	time.AfterFunc(time.Until(message.DeleteAt), func() {
+		err := s.deleteMessage(message.Channel, message.Timestamp)
+		if err != nil {
+			s.reportError(err)
+		}
+
+		if s.defaults.Store == nil {
+			return
+		}
+
+		err = s.updateTemporaryMessages(func(messages []*temporaryMessage) []*temporaryMessage {
+			remaining := messages[:0]
+			for _, pending := range messages {
+				if pending.Channel != message.Channel || pending.Timestamp != message.Timestamp {
+					remaining = append(remaining, pending)
+				}
+			}
+			return remaining
+		})
+		if err != nil {
+			s.reportError(err)
+		}
+	})
+}
+
+// resumeDeletions schedules the deletion of the temporary messages kept in the store by a previous run
+func (s *Slacker) resumeDeletions() {
+	if s.defaults.Store == nil {
+		return
+	}
+
+	s.temporaryMutex.Lock()
+	messages, err := s.loadTemporaryMessages()
+	s.temporaryMutex.Unlock()
+
+	if err != nil {
+		s.reportError(err)
+		return
+	}
+
+	for _, message := range messages {
+		s.scheduleDeletion(message)
+	}
+}
+
+// updateTemporaryMessages replaces the temporary messages kept in the store with the ones returned by update
+func (s *Slacker) updateTemporaryMessages(update func(messages []*temporaryMessage) []*temporaryMessage) error {
+	s.temporaryMutex.Lock()
+	defer s.temporaryMutex.Unlock()
+
+	messages, err := s.loadTemporaryMessages()
+	if err != nil {
+		return err
+	}
+
+	messages = update(messages)
+	if len(messages) == 0 {
+		return s.defaults.Store.Delete(temporaryKey)
+	}
+
+	data, err := json.Marshal(messages)
+	if err != nil {
+		return err
+	}
+	return s.defaults.Store.Set(temporaryKey, data, 0)
+}
+
+func (s *Slacker) loadTemporaryMessages() ([]*temporaryMessage, error) {
+	data, ok, err := s.defaults.Store.Get(temporaryKey)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	var messages []*temporaryMessage
+	err = json.Unmarshal(data, &messages)
+	return messages, err
+}
+
+// deleteMessage deletes the bot's message with the timestamp in the channel
+func (s *Slacker) deleteMessage(channel string, timestamp string) error {
+	return s.queryAPI(chatDeleteMethod, url.Values{channelField: {channel}, tsField: {timestamp}}, nil)
+}
@@ -0,0 +1,95 @@
+package slacker
+
+import (
+	"net/url"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	conversationsRepliesMethod = "conversations.replies"
+	tsField                    = "ts"
+	limitField                 = "limit"
+	notInThread                = "message is not in a thread"
+	parentNotFound             = "thread parent message not found"
+)
+
+// threadScope restricts where in a conversation a command applies
+type threadScope int
+
+const (
+	anywhere threadScope = iota
+	threadsOnly
+	topLevelOnly
+)
+
+// WithThreadsOnly restricts the command to messages sent in threads
+func WithThreadsOnly() CommandOption {
+	return func(command *BotCommand) {
+		command.threadScope = threadsOnly
+	}
+}
+
+// WithTopLevelOnly restricts the command to messages sent at the top level of the channel, outside threads
+func WithTopLevelOnly() CommandOption {
+	return func(command *BotCommand) {
+		command.threadScope = topLevelOnly
+	}
+}
+
+// isInScope determines whether the command applies to the message, depending on whether it was sent in a thread
+func (c *BotCommand) isInScope(event *slack.MessageEvent) bool {
+	switch c.threadScope {
+	case threadsOnly:
+		return isInThread(event)
+	case topLevelOnly:
+		return !isInThread(event)
+	}
+	return true
+}
+
+// isInThread determines whether the message was sent as a reply in a thread
+func isInThread(event *slack.MessageEvent) bool {
+	return len(event.ThreadTimestamp) > 0 && event.ThreadTimestamp != event.Timestamp
+}
+
+// conversationsRepliesResponse is the conversations.replies response
+type conversationsRepliesResponse struct {
+	Messages []slack.Message `json:"messages"`
+}
+
+// ThreadParent returns the message that started the thread with the timestamp in the channel
+func (s *Slacker) ThreadParent(channel string, threadTimestamp string) (*slack.Message, error) {
+	response := &conversationsRepliesResponse{}
+	err := s.queryAPI(conversationsRepliesMethod, url.Values{channelField: {channel}, tsField: {threadTimestamp}, limitField: {"1"}}, response)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, message := range response.Messages {
+		if message.Timestamp == threadTimestamp {
+			return &message, nil
+		}
+	}
+	return nil, ErrParentNotFound
+}
+
+// ThreadTimestamp returns the timestamp of the thread the message was sent in, or an empty string at the top level
+func (r *Request) ThreadTimestamp() string {
+	if !isInThread(r.Event) {
+		return empty
+	}
+	return r.Event.ThreadTimestamp
+}
+
+// ThreadParent returns the message that started the thread the message was sent in
+func (r *Request) ThreadParent() (*slack.Message, error) {
+	if r.slacker == nil {
+		return nil, ErrNoBotClient
+	}
+
+	if !isInThread(r.Event) {
+		return nil, ErrNotInThread
+	}
+	return r.slacker.ThreadParent(r.Event.Channel, r.Event.ThreadTimestamp)
+}
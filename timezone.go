@@ -0,0 +1,47 @@
+package slacker
+
+import (
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// UserLocation returns the timezone of the user who sent the message, from their cached profile.
+// When the timezone database does not know the user's timezone, a fixed zone with their current offset is returned
+func (r *Request) UserLocation() (*time.Location, error) {
+	user, err := r.User()
+	if err != nil {
+		return nil, err
+	}
+	return locationOf(user), nil
+}
+
+// userLocation returns the timezone of the user, or UTC if their profile could not be looked up
+func (s *Slacker) userLocation(userID string) *time.Location {
+	user, err := s.UserInfo(userID)
+	if err != nil {
+		s.reportError(err)
+		return time.UTC
+	}
+	return locationOf(user)
+}
+
+// locationOf returns the timezone of the user, or a fixed zone with their current offset
+// when the timezone database does not know it
+func locationOf(user *slack.User) *time.Location {
+	location, err := time.LoadLocation(user.TZ)
+	if err != nil || len(user.TZ) == 0 {
+		return time.FixedZone(user.TZLabel, user.TZOffset)
+	}
+	return location
+}
+
+// FormatTime formats the time with the layout in the timezone of the user who sent the message,
+// or in UTC if their timezone could not be looked up
+func (r *Request) FormatTime(t time.Time, layout string) string {
+	location, err := r.UserLocation()
+	if err != nil {
+		return t.UTC().Format(layout)
+	}
+	return t.In(location).Format(layout)
+}
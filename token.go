@@ -0,0 +1,171 @@
+package slacker
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	oauthAccessMethod     = "oauth.v2.access"
+	refreshTokenGrantType = "refresh_token"
+	clientIDField         = "client_id"
+	clientSecretField     = "client_secret"
+	grantTypeField        = "grant_type"
+	refreshTokenField     = "refresh_token"
+	tokenExpiryMargin     = time.Minute
+)
+
+// TokenSource provides the token used for Web API calls, allowing tokens to be rotated
+type TokenSource interface {
+	Token() (string, error)
+}
+
+// StaticTokenSource creates a token source that always provides the same token
+func StaticTokenSource(token string) TokenSource {
+	return staticTokenSource(token)
+}
+
+type staticTokenSource string
+
+// Token returns the static token
+func (t staticTokenSource) Token() (string, error) {
+	return string(t), nil
+}
+
+// NewRefreshingTokenSource creates a token source for apps with token rotation enabled.
+// Expiring tokens are exchanged for new ones using the refresh token shortly before they expire
+func NewRefreshingTokenSource(clientID string, clientSecret string, refreshToken string) *RefreshingTokenSource {
+	return &RefreshingTokenSource{clientID: clientID, clientSecret: clientSecret, refreshToken: refreshToken, client: http.DefaultClient}
+}
+
+// WithHTTPClient sets the client used to refresh tokens, returning the token source
+func (t *RefreshingTokenSource) WithHTTPClient(client *http.Client) *RefreshingTokenSource {
+	t.client = client
+	return t
+}
+
+// RefreshingTokenSource contains the app credentials and the current token
+type RefreshingTokenSource struct {
+	mutex        sync.Mutex
+	clientID     string
+	clientSecret string
+	refreshToken string
+	token        string
+	expiry       time.Time
+	client       *http.Client
+}
+
+// refreshResponse is the oauth.v2.access response to a refresh token exchange
+type refreshResponse struct {
+	slack.SlackResponse
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// Token returns the current token, refreshing it if it is about to expire
+func (t *RefreshingTokenSource) Token() (string, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if len(t.token) > 0 && time.Now().Add(tokenExpiryMargin).Before(t.expiry) {
+		return t.token, nil
+	}
+
+	values := url.Values{
+		clientIDField:     {t.clientID},
+		clientSecretField: {t.clientSecret},
+		grantTypeField:    {refreshTokenGrantType},
+		refreshTokenField: {t.refreshToken},
+	}
+
+	response, err := t.client.PostForm(slack.APIURL+oauthAccessMethod, values)
+	if err != nil {
+		return empty, err
+	}
+	defer response.Body.Close()
+
+	result := &refreshResponse{}
+	err = json.NewDecoder(response.Body).Decode(result)
+	if err != nil {
+		return empty, err
+	}
+
+	if !result.Ok {
+		return empty, errors.New(result.Error)
+	}
+
+	t.token = result.AccessToken
+	t.refreshToken = result.RefreshToken
+	t.expiry = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	return t.token, nil
+}
+
+// apiToken consults the token source, updating the Web API client when the token was rotated
+func (s *Slacker) apiToken() (string, error) {
+	token, err := s.defaults.TokenSource.Token()
+	if err != nil {
+		return empty, err
+	}
+
+	s.tokenMutex.Lock()
+	defer s.tokenMutex.Unlock()
+
+	if token != s.token {
+		s.token = token
+		s.client.Store(newAPIClient(token, s.defaults))
+	}
+	return token, nil
+}
+
+// newAPIClient creates a Web API client for the token, making requests with the configured HTTP client
+func newAPIClient(token string, defaults *ClientDefaults) *slack.Client {
+	return slack.New(token, slack.OptionHTTPClient(defaults.HTTPClient))
+}
+
+// APIClient returns the Web API client for the current token, consulting the token source first.
+// The Client field keeps the token the bot was created with, handlers use Request.Client instead
+func (s *Slacker) APIClient() *slack.Client {
+	_, err := s.apiToken()
+	if err != nil {
+		s.reportError(err)
+	}
+	return s.client.Load().(*slack.Client)
+}
+
+// rotatingClient posts messages and authenticates through the Web API client for the current token
+type rotatingClient struct {
+	slacker *Slacker
+}
+
+// PostMessage posts the message with the current client
+func (c *rotatingClient) PostMessage(channel string, options ...slack.MsgOption) (string, string, error) {
+	return c.slacker.APIClient().PostMessage(channel, options...)
+}
+
+// PostEphemeral posts the ephemeral message with the current client
+func (c *rotatingClient) PostEphemeral(channel string, userID string, options ...slack.MsgOption) (string, error) {
+	return c.slacker.APIClient().PostEphemeral(channel, userID, options...)
+}
+
+// AuthTest calls auth.test with the current token
+func (c *rotatingClient) AuthTest() (*slack.AuthTestResponse, error) {
+	response, _, err := c.AuthTestScopes()
+	return response, err
+}
+
+// AuthTestScopes calls auth.test with the current token, also returning the scopes granted to it
+func (c *rotatingClient) AuthTestScopes() (*slack.AuthTestResponse, []string, error) {
+	response := &slack.AuthTestResponse{}
+	header, err := c.slacker.requestAPI(authTestMethod, formContentType, nil, response)
+	if err != nil {
+		return nil, nil, err
+	}
+	return response, parseScopes(header.Get(scopesHeader)), nil
+}
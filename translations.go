@@ -0,0 +1,47 @@
+package slacker
+
+import (
+	"github.com/shomali11/commander"
+	"github.com/shomali11/slacker/format"
+)
+
+// translation is an alternate usage of a command in another language
+type translation struct {
+	language    string
+	usage       string
+	description string
+	command     *commander.Command
+}
+
+// WithTranslation adds an alternate usage and description of the command in the language, so that it can be
+// used in either language. The usage must declare the same parameters, whose allowed values and optional markers
+// are those of the original usage. The help lists each translation under the command
+func WithTranslation(language string, usage string, description string) CommandOption {
+	return func(command *BotCommand) {
+		pattern, _ := parseUsage(usage)
+		command.translations = append(command.translations, &translation{
+			language:    language,
+			usage:       usage,
+			description: description,
+			command:     commander.NewCommand(pattern),
+		})
+	}
+}
+
+// patterns returns the commands matching the original usage and its translations
+func (c *BotCommand) patterns() []*commander.Command {
+	patterns := []*commander.Command{c.command}
+	for _, translation := range c.translations {
+		patterns = append(patterns, translation.command)
+	}
+	return patterns
+}
+
+// translationsHelp returns the usage lines of the translations, with their language
+func (c *BotCommand) translationsHelp() string {
+	text := empty
+	for _, translation := range c.translations {
+		text += newLine + c.usageLine(translation.command.Tokenize(), translation.description) + space + format.Italic(translation.language)
+	}
+	return text
+}
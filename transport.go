@@ -0,0 +1,47 @@
+package slacker
+
+import (
+	"context"
+
+	"github.com/slack-go/slack"
+)
+
+// Transport is the way a message reached the bot
+type Transport string
+
+const (
+	// RTMTransport is a message received over the Real-Time Messaging connection
+	RTMTransport Transport = "rtm"
+
+	// EventsTransport is a message posted to the Events API request URL or received over Socket Mode
+	EventsTransport Transport = "events"
+
+	// SlashCommandTransport is a slash command posted to the slash commands request URL or received over Socket Mode
+	SlashCommandTransport Transport = "slash_command"
+)
+
+// transportKey is the context key of the transport details of a message
+type transportKey struct{}
+
+// transportDetails are the transport specific details of a message, carried by its context
+type transportDetails struct {
+	transport    Transport
+	slashCommand *SlashCommand
+	files        []*slack.File
+	enterpriseID string
+	userTeamID   string
+}
+
+// withTransport returns a context carrying the transport details
+func withTransport(ctx context.Context, details *transportDetails) context.Context {
+	return context.WithValue(ctx, transportKey{}, details)
+}
+
+// transportFrom returns the transport details carried by the context, if any
+func transportFrom(ctx context.Context) *transportDetails {
+	details, ok := ctx.Value(transportKey{}).(*transportDetails)
+	if !ok {
+		return &transportDetails{}
+	}
+	return details
+}
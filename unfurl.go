@@ -0,0 +1,103 @@
+package slacker
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	linkSharedEventType = "link_shared"
+	chatUnfurlMethod    = "chat.unfurl"
+	domainSeparator     = "."
+)
+
+// Unfurler returns the Block Kit preview of a link, or no blocks to leave it as it is
+type Unfurler func(url string) ([]slack.Block, error)
+
+// LinkSharedEvent is received when a message contains links to a domain registered for the app
+type LinkSharedEvent struct {
+	Channel   string        `json:"channel"`
+	User      string        `json:"user"`
+	MessageTs string        `json:"message_ts"`
+	Links     []*SharedLink `json:"links"`
+}
+
+// SharedLink is a link shared in a message
+type SharedLink struct {
+	Domain string `json:"domain"`
+	URL    string `json:"url"`
+}
+
+// unfurlPayload is the payload accepted by chat.unfurl
+type unfurlPayload struct {
+	Channel   string             `json:"channel"`
+	Timestamp string             `json:"ts"`
+	Unfurls   map[string]*unfurl `json:"unfurls"`
+}
+
+// unfurl is the preview of a link
+type unfurl struct {
+	Blocks slack.Blocks `json:"blocks"`
+}
+
+// Unfurl previews links to the domain or its subdomains with the blocks returned by the unfurler,
+// requires the Events API and the domain to be registered in the app's settings
+func (s *Slacker) Unfurl(domain string, unfurler Unfurler) {
+	s.unfurlers[strings.ToLower(domain)] = unfurler
+}
+
+// unfurler returns the unfurler registered for the domain or one of its parent domains
+func (s *Slacker) unfurler(domain string) Unfurler {
+	domain = strings.ToLower(domain)
+	for len(domain) > 0 {
+		unfurler, ok := s.unfurlers[domain]
+		if ok {
+			return unfurler
+		}
+
+		index := strings.Index(domain, domainSeparator)
+		if index < 0 {
+			break
+		}
+		domain = domain[index+1:]
+	}
+	return nil
+}
+
+func (s *Slacker) handleLinkShared(data json.RawMessage) {
+	event := &LinkSharedEvent{}
+	err := json.Unmarshal(data, event)
+	if err != nil {
+		s.reportError(err)
+		return
+	}
+
+	unfurls := make(map[string]*unfurl)
+	for _, link := range event.Links {
+		unfurler := s.unfurler(link.Domain)
+		if unfurler == nil {
+			continue
+		}
+
+		blocks, err := unfurler(link.URL)
+		if err != nil {
+			s.reportError(err)
+			continue
+		}
+
+		if len(blocks) > 0 {
+			unfurls[link.URL] = &unfurl{Blocks: slack.Blocks{BlockSet: blocks}}
+		}
+	}
+
+	if len(unfurls) == 0 {
+		return
+	}
+
+	err = s.callAPI(chatUnfurlMethod, &unfurlPayload{Channel: event.Channel, Timestamp: event.MessageTs, Unfurls: unfurls}, nil)
+	if err != nil {
+		s.reportError(err)
+	}
+}
@@ -0,0 +1,174 @@
+package slacker
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	usersLookupByEmailMethod = "users.lookupByEmail"
+	emailField               = "email"
+	userMentionPrefix        = "@"
+	emailKeyPrefix           = "email:"
+	nameKeyPrefix            = "name:"
+	idKeyPrefix              = "id:"
+	userCacheTTL             = time.Hour
+	userCacheSweepInterval   = time.Minute
+	userListInterval         = time.Minute
+	userNotFound             = "users_not_found"
+)
+
+// userLookupResponse is the users.lookupByEmail response
+type userLookupResponse struct {
+	User *slack.User `json:"user"`
+}
+
+// newUserCache creates a cache keeping users for the given duration
+func newUserCache(ttl time.Duration) *userCache {
+	return &userCache{ttl: ttl, entries: make(map[string]*cachedUser)}
+}
+
+// userCache keeps users looked up by email or name, along with when the workspace's users were last listed
+type userCache struct {
+	mutex     sync.Mutex
+	ttl       time.Duration
+	entries   map[string]*cachedUser
+	lastSweep time.Time
+	listMutex sync.Mutex
+	listedAt  time.Time
+}
+
+// cachedUser is a user and the time it should be looked up again
+type cachedUser struct {
+	user   *slack.User
+	expiry time.Time
+}
+
+// Get returns the user cached under the key, if it has not expired
+func (c *userCache) Get(key string) *slack.User {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+
+	if time.Now().After(entry.expiry) {
+		delete(c.entries, key)
+		return nil
+	}
+	return entry.user
+}
+
+// Set caches the user under the key
+func (c *userCache) Set(key string, user *slack.User) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	c.entries[key] = &cachedUser{user: user, expiry: now.Add(c.ttl)}
+
+	// Expired users are evicted from time to time, as those never looked up again would otherwise be kept forever
+	if now.Sub(c.lastSweep) < userCacheSweepInterval {
+		return
+	}
+	c.lastSweep = now
+	for key, entry := range c.entries {
+		if now.After(entry.expiry) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// UserInfo returns the user with the ID, including their profile and timezone. Users are cached for an hour
+func (s *Slacker) UserInfo(userID string) (*slack.User, error) {
+	key := idKeyPrefix + userID
+	user := s.users.Get(key)
+	if user != nil {
+		return user, nil
+	}
+
+	user, err := s.APIClient().GetUserInfo(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.users.Set(key, user)
+	return user, nil
+}
+
+// UserByEmail returns the user with the email address. Users are cached for an hour
+func (s *Slacker) UserByEmail(email string) (*slack.User, error) {
+	key := emailKeyPrefix + strings.ToLower(email)
+	user := s.users.Get(key)
+	if user != nil {
+		return user, nil
+	}
+
+	response := &userLookupResponse{}
+	err := s.queryAPI(usersLookupByEmailMethod, url.Values{emailField: {email}}, response)
+	if err != nil {
+		return nil, err
+	}
+
+	s.users.Set(key, response.User)
+	return response.User, nil
+}
+
+// UserByName returns the user with the handle, with or without a leading @, or with the display name.
+// Looking up a name not seen yet lists the workspace's users, which are then cached for an hour.
+// The users are listed at most once a minute, names not found in the meantime fail with ErrUserNotFound
+func (s *Slacker) UserByName(handle string) (*slack.User, error) {
+	key := nameKeyPrefix + strings.ToLower(strings.TrimPrefix(handle, userMentionPrefix))
+	user := s.users.Get(key)
+	if user != nil {
+		return user, nil
+	}
+
+	// Concurrent lookups wait for a single listing, then find the name in the cache
+	s.users.listMutex.Lock()
+	defer s.users.listMutex.Unlock()
+
+	user = s.users.Get(key)
+	if user != nil {
+		return user, nil
+	}
+
+	if time.Since(s.users.listedAt) < userListInterval {
+		return nil, ErrUserNotFound
+	}
+
+	users, err := s.APIClient().GetUsers()
+	if err != nil {
+		return nil, err
+	}
+	s.users.listedAt = time.Now()
+
+	for i := range users {
+		user := &users[i]
+		if len(user.Profile.DisplayName) > 0 {
+			s.users.Set(nameKeyPrefix+strings.ToLower(user.Profile.DisplayName), user)
+		}
+		s.users.Set(nameKeyPrefix+strings.ToLower(user.Name), user)
+	}
+
+	user = s.users.Get(key)
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// User returns the user who sent the message, with their profile, timezone and whether they are an admin or a bot.
+// Users are cached for an hour, so handlers can call it freely
+func (r *Request) User() (*slack.User, error) {
+	if r.slacker == nil {
+		return nil, ErrNoBotClient
+	}
+	return r.slacker.UserInfo(r.Event.User)
+}
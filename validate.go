@@ -0,0 +1,106 @@
+package slacker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	authTestMethod   = "auth.test"
+	scopesHeader     = "X-OAuth-Scopes"
+	appTokenPrefix   = "xapp-"
+	scopeSeparator   = ","
+	tokenErrorFormat = "token validation failed: %s"
+	emptyToken       = "no token provided"
+	appToken         = "app-level tokens (xapp-) cannot call the Web API, use the bot token (xoxb-)"
+)
+
+// tokenErrorDescriptions explains the auth.test errors caused by the token
+var tokenErrorDescriptions = map[string]string{
+	"not_authed":             "no token provided",
+	"invalid_auth":           "the token is invalid",
+	"account_inactive":       "the token belongs to a deactivated user or workspace",
+	"token_revoked":          "the token has been revoked",
+	"token_expired":          "the token has expired",
+	"not_allowed_token_type": "the token type is not allowed, use the bot token (xoxb-)",
+}
+
+// ValidateToken calls auth.test through the Authenticator and returns a descriptive error if the token cannot be used,
+// wrapping ErrInvalidAuth, or ErrMissingScopes when it lacks any of the required scopes.
+// The scopes are only checked when the Authenticator is a ScopedAuthenticator, as the default one is.
+// Listen calls it before connecting
+func (s *Slacker) ValidateToken() error {
+	token, err := s.apiToken()
+	if err != nil {
+		return fmt.Errorf(tokenErrorFormat, err.Error())
+	}
+
+	if len(token) == 0 {
+		return fmt.Errorf(wrappedFormat, ErrInvalidAuth, emptyToken)
+	}
+
+	if strings.HasPrefix(token, appTokenPrefix) {
+		return fmt.Errorf(wrappedFormat, ErrInvalidAuth, appToken)
+	}
+
+	var response *slack.AuthTestResponse
+	var granted []string
+	scoped, isScoped := s.authenticator.(ScopedAuthenticator)
+	if isScoped {
+		response, granted, err = scoped.AuthTestScopes()
+	} else {
+		response, err = s.authenticator.AuthTest()
+	}
+
+	if err != nil {
+		description, ok := tokenErrorDescriptions[err.Error()]
+		if !ok {
+			return fmt.Errorf(tokenErrorFormat, err.Error())
+		}
+		return fmt.Errorf(wrappedFormat, ErrInvalidAuth, description)
+	}
+
+	s.authOnce.Do(func() {
+		s.userID = response.UserID
+	})
+
+	if !isScoped {
+		return nil
+	}
+
+	missingScopes := missingScopes(granted, s.defaults.RequiredScopes)
+	if len(missingScopes) > 0 {
+		return fmt.Errorf(wrappedFormat, ErrMissingScopes, strings.Join(missingScopes, scopeSeparator+space))
+	}
+	return nil
+}
+
+// parseScopes returns the scopes of the comma separated list
+func parseScopes(list string) []string {
+	var scopes []string
+	for _, scope := range strings.Split(list, scopeSeparator) {
+		scope = strings.TrimSpace(scope)
+		if len(scope) > 0 {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// missingScopes returns the required scopes not granted
+func missingScopes(granted []string, required []string) []string {
+	grantedScopes := make(map[string]bool)
+	for _, scope := range granted {
+		grantedScopes[scope] = true
+	}
+
+	var missing []string
+	for _, scope := range required {
+		if !grantedScopes[scope] {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,72 @@
+package slacker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeAuthenticator answers auth.test without calling the Web API
+type fakeAuthenticator struct {
+	response *slack.AuthTestResponse
+	err      error
+	calls    int
+}
+
+func (a *fakeAuthenticator) AuthTest() (*slack.AuthTestResponse, error) {
+	a.calls++
+	return a.response, a.err
+}
+
+// fakeScopedAuthenticator also reports the scopes granted to the token
+type fakeScopedAuthenticator struct {
+	fakeAuthenticator
+	scopes []string
+}
+
+func (a *fakeScopedAuthenticator) AuthTestScopes() (*slack.AuthTestResponse, []string, error) {
+	response, err := a.AuthTest()
+	return response, a.scopes, err
+}
+
+func TestValidateToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		authenticator Authenticator
+		scopes        []string
+		calls         int
+		err           error
+	}{
+		{name: "valid", token: "xoxb-test", authenticator: &fakeAuthenticator{response: &slack.AuthTestResponse{UserID: "U1"}}, calls: 1},
+		{name: "invalid", token: "xoxb-test", authenticator: &fakeAuthenticator{err: errors.New("invalid_auth")}, calls: 1, err: ErrInvalidAuth},
+		{name: "app token", token: "xapp-test", authenticator: &fakeAuthenticator{}, err: ErrInvalidAuth},
+		{name: "granted scopes", token: "xoxb-test", authenticator: &fakeScopedAuthenticator{fakeAuthenticator: fakeAuthenticator{response: &slack.AuthTestResponse{UserID: "U1"}}, scopes: []string{"chat:write", "users:read"}}, scopes: []string{"chat:write"}, calls: 1},
+		{name: "missing scopes", token: "xoxb-test", authenticator: &fakeScopedAuthenticator{fakeAuthenticator: fakeAuthenticator{response: &slack.AuthTestResponse{UserID: "U1"}}, scopes: []string{"chat:write"}}, scopes: []string{"chat:write", "users:read"}, calls: 1, err: ErrMissingScopes},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bot := NewClient(test.token, WithAuthenticator(test.authenticator), WithRequiredScopes(test.scopes...))
+
+			err := bot.ValidateToken()
+			if test.err == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, "U1", bot.userID)
+			} else {
+				assert.True(t, errors.Is(err, test.err), err)
+			}
+
+			calls := 0
+			switch authenticator := test.authenticator.(type) {
+			case *fakeAuthenticator:
+				calls = authenticator.calls
+			case *fakeScopedAuthenticator:
+				calls = authenticator.calls
+			}
+			assert.Equal(t, test.calls, calls)
+		})
+	}
+}
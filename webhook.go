@@ -0,0 +1,71 @@
+package slacker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	jsonContentType     = "application/json"
+	webhookStatusFormat = "webhook responded with status %d"
+)
+
+// Webhook creates a new sender that posts messages to a Slack incoming webhook
+func Webhook(url string) *WebhookSender {
+	return WebhookWithClient(url, http.DefaultClient)
+}
+
+// WebhookWithClient creates a new sender that posts messages to a Slack incoming webhook using the HTTP client
+func WebhookWithClient(url string, client *http.Client) *WebhookSender {
+	return &WebhookSender{url: url, client: client}
+}
+
+// WebhookSender posts messages to the channel an incoming webhook is bound to
+type WebhookSender struct {
+	url    string
+	client *http.Client
+}
+
+// webhookMessage is the payload accepted by incoming webhooks
+type webhookMessage struct {
+	Text         string             `json:"text"`
+	UnfurlLinks  bool               `json:"unfurl_links"`
+	UnfurlMedia  bool               `json:"unfurl_media"`
+	ResponseType string             `json:"response_type,omitempty"`
+	Attachments  []slack.Attachment `json:"attachments,omitempty"`
+	Blocks       []slack.Block      `json:"blocks,omitempty"`
+}
+
+// Reply posts a message to the webhook's channel
+func (w *WebhookSender) Reply(text string, options ...ReplyOption) error {
+	defaults := NewReplyDefaults(options...)
+	return w.post(&webhookMessage{Text: text, UnfurlLinks: defaults.UnfurlLinks, UnfurlMedia: defaults.UnfurlMedia, Attachments: defaults.Attachments, Blocks: defaults.Blocks})
+}
+
+// ReportError posts a formatted error message to the webhook's channel
+func (w *WebhookSender) ReportError(err error) error {
+	defaults := NewReplyDefaults()
+	return w.post(&webhookMessage{Text: fmt.Sprintf(errorFormat, err.Error()), UnfurlLinks: defaults.UnfurlLinks, UnfurlMedia: defaults.UnfurlMedia})
+}
+
+func (w *WebhookSender) post(message *webhookMessage) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	response, err := w.client.Post(w.url, jsonContentType, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf(webhookStatusFormat, response.StatusCode)
+	}
+	return nil
+}
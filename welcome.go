@@ -0,0 +1,98 @@
+package slacker
+
+import (
+	"encoding/json"
+
+	"github.com/shomali11/slacker/format"
+	"github.com/slack-go/slack"
+)
+
+const (
+	memberJoinedChannelEventType = "member_joined_channel"
+	channelJoinSubtype           = "channel_join"
+	joinMessageSearchLimit       = 20
+)
+
+// MemberJoinedChannelEvent is received when a user joins a channel the bot is in
+type MemberJoinedChannelEvent struct {
+	User           string `json:"user"`
+	Channel        string `json:"channel"`
+	ChannelType    string `json:"channel_type"`
+	Team           string `json:"team"`
+	Inviter        string `json:"inviter"`
+	EventTimestamp string `json:"event_ts"`
+}
+
+// OnMemberJoinedChannel handle users joining a channel the bot is in, requires the Events API
+func (s *Slacker) OnMemberJoinedChannel(memberJoinedChannelHandler func(event *MemberJoinedChannelEvent)) {
+	s.memberJoinedChannelHandler = memberJoinedChannelHandler
+}
+
+// WelcomeDirectly returns a handler for OnMemberJoinedChannel sending the text to new members in a direct message
+func (s *Slacker) WelcomeDirectly(text string) func(event *MemberJoinedChannelEvent) {
+	return func(event *MemberJoinedChannelEvent) {
+		channel, err := s.directChannel(event.User)
+		if err != nil {
+			s.reportError(err)
+			return
+		}
+		s.postMessage(channel, text)
+	}
+}
+
+// WelcomeInThread returns a handler for OnMemberJoinedChannel greeting new members in the thread of the message
+// announcing they joined the channel, mentioning them before the text. When that message cannot be found,
+// as when join messages are hidden, the greeting is posted in the channel
+func (s *Slacker) WelcomeInThread(text string) func(event *MemberJoinedChannelEvent) {
+	return func(event *MemberJoinedChannelEvent) {
+		var options []ReplyOption
+		timestamp, err := s.joinMessageTimestamp(event)
+		if err != nil {
+			s.reportError(err)
+		}
+
+		if len(timestamp) > 0 {
+			options = append(options, WithThreadTimestamp(timestamp))
+		}
+
+		s.postMessage(event.Channel, format.Mention(event.User)+space+text, NewReplyDefaults(options...).msgOptions()...)
+	}
+}
+
+// joinMessageTimestamp returns the timestamp of the latest message announcing the member joined the channel,
+// or empty if there is none among the recent messages
+func (s *Slacker) joinMessageTimestamp(event *MemberJoinedChannelEvent) (string, error) {
+	history, err := s.APIClient().GetConversationHistory(&slack.GetConversationHistoryParameters{
+		ChannelID: event.Channel,
+		Limit:     joinMessageSearchLimit,
+	})
+	if err != nil {
+		return empty, err
+	}
+
+	for _, message := range history.Messages {
+		if message.SubType == channelJoinSubtype && message.User == event.User {
+			return message.Timestamp, nil
+		}
+	}
+	return empty, nil
+}
+
+func (s *Slacker) handleMemberJoinedChannel(data json.RawMessage) {
+	if s.memberJoinedChannelHandler == nil {
+		return
+	}
+
+	event := &MemberJoinedChannelEvent{}
+	err := json.Unmarshal(data, event)
+	if err != nil {
+		s.reportError(err)
+		return
+	}
+
+	// The bot joining a channel is not a new member to welcome
+	if event.User == s.botUserID() {
+		return
+	}
+	s.memberJoinedChannelHandler(event)
+}
@@ -0,0 +1,187 @@
+package slacker
+
+import (
+	"encoding/json"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	workflowStepEdit             = "workflow_step_edit"
+	workflowStepExecuteEventType = "workflow_step_execute"
+	workflowStepViewType         = "workflow_step"
+	textOutputType               = "text"
+	workflowsUpdateStepMethod    = "workflows.updateStep"
+	workflowsStepCompletedMethod = "workflows.stepCompleted"
+	workflowsStepFailedMethod    = "workflows.stepFailed"
+)
+
+// WorkflowStep contains the handlers of a custom step exposed to Workflow Builder
+type WorkflowStep struct {
+	// Edit is called when a step is added or edited and should open its configuration
+	Edit func(edit *WorkflowStepEdit)
+
+	// Save is called when the configuration is submitted and should update the step
+	Save func(save *WorkflowStepSave)
+
+	// Execute is called when a workflow reaches the step and should complete or fail it
+	Execute func(execution *WorkflowStepExecution)
+}
+
+// NewWorkflowStepInput creates an input, the value may reference workflow variables
+func NewWorkflowStepInput(value interface{}) *WorkflowStepInput {
+	return &WorkflowStepInput{Value: value}
+}
+
+// WorkflowStepInput is an input of a workflow step
+type WorkflowStepInput struct {
+	Value                   interface{} `json:"value"`
+	SkipVariableReplacement bool        `json:"skip_variable_replacement,omitempty"`
+}
+
+// NewWorkflowStepOutput creates a text output that later steps can reference
+func NewWorkflowStepOutput(name string, label string) *WorkflowStepOutput {
+	return &WorkflowStepOutput{Name: name, Type: textOutputType, Label: label}
+}
+
+// WorkflowStepOutput describes a value produced by a workflow step
+type WorkflowStepOutput struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Label string `json:"label"`
+}
+
+// workflowStepPayload is the step included in workflow interactions and events
+type workflowStepPayload struct {
+	WorkflowStepEditID    string                        `json:"workflow_step_edit_id,omitempty"`
+	WorkflowStepExecuteID string                        `json:"workflow_step_execute_id,omitempty"`
+	Inputs                map[string]*WorkflowStepInput `json:"inputs,omitempty"`
+	Outputs               []*WorkflowStepOutput         `json:"outputs,omitempty"`
+}
+
+// workflowStepExecuteEvent is received when a workflow reaches a step
+type workflowStepExecuteEvent struct {
+	CallbackID   string               `json:"callback_id"`
+	WorkflowStep *workflowStepPayload `json:"workflow_step"`
+}
+
+// workflowStepView is the configuration view of a workflow step
+type workflowStepView struct {
+	Type       string       `json:"type"`
+	CallbackID string       `json:"callback_id"`
+	Blocks     slack.Blocks `json:"blocks"`
+}
+
+// workflowStepResult is the payload accepted by workflows.stepCompleted and workflows.stepFailed
+type workflowStepResult struct {
+	WorkflowStepExecuteID string                 `json:"workflow_step_execute_id"`
+	Outputs               map[string]interface{} `json:"outputs,omitempty"`
+	Error                 *workflowStepError     `json:"error,omitempty"`
+}
+
+// workflowStepError describes why a workflow step failed
+type workflowStepError struct {
+	Message string `json:"message"`
+}
+
+// WorkflowStepEdit contains the current configuration of a step being edited
+type WorkflowStepEdit struct {
+	Inputs     map[string]*WorkflowStepInput
+	Outputs    []*WorkflowStepOutput
+	callbackID string
+	triggerID  string
+	slacker    *Slacker
+}
+
+// Configure opens the step's configuration view made of the blocks
+func (e *WorkflowStepEdit) Configure(blocks ...slack.Block) error {
+	view := &workflowStepView{Type: workflowStepViewType, CallbackID: e.callbackID, Blocks: slack.Blocks{BlockSet: blocks}}
+	return e.slacker.callAPI(viewsOpenMethod, &viewOpenPayload{TriggerID: e.triggerID, View: view}, nil)
+}
+
+// WorkflowStepSave contains the submitted configuration of a step
+type WorkflowStepSave struct {
+	Values  map[string]map[string]*ViewStateValue
+	editID  string
+	slacker *Slacker
+}
+
+// Update saves the step's inputs and the outputs it produces
+func (s *WorkflowStepSave) Update(inputs map[string]*WorkflowStepInput, outputs ...*WorkflowStepOutput) error {
+	step := &workflowStepPayload{WorkflowStepEditID: s.editID, Inputs: inputs, Outputs: outputs}
+	return s.slacker.callAPI(workflowsUpdateStepMethod, step, nil)
+}
+
+// WorkflowStepExecution contains the inputs of a step being executed
+type WorkflowStepExecution struct {
+	Inputs    map[string]*WorkflowStepInput
+	Outputs   []*WorkflowStepOutput
+	executeID string
+	slacker   *Slacker
+}
+
+// Complete marks the step as completed, providing values for its outputs
+func (e *WorkflowStepExecution) Complete(outputs map[string]interface{}) error {
+	result := &workflowStepResult{WorkflowStepExecuteID: e.executeID, Outputs: outputs}
+	return e.slacker.callAPI(workflowsStepCompletedMethod, result, nil)
+}
+
+// Fail marks the step as failed with the message
+func (e *WorkflowStepExecution) Fail(message string) error {
+	result := &workflowStepResult{WorkflowStepExecuteID: e.executeID, Error: &workflowStepError{Message: message}}
+	return e.slacker.callAPI(workflowsStepFailedMethod, result, nil)
+}
+
+// WorkflowStep define a custom Workflow Builder step, requires the Events API and interactivity
+func (s *Slacker) WorkflowStep(callbackID string, step *WorkflowStep) {
+	s.workflowSteps[callbackID] = step
+}
+
+func (s *Slacker) handleWorkflowStepEdit(payload *interaction) {
+	step, ok := s.workflowSteps[payload.CallbackID]
+	if !ok || step.Edit == nil || payload.WorkflowStep == nil {
+		return
+	}
+
+	step.Edit(&WorkflowStepEdit{
+		Inputs:     payload.WorkflowStep.Inputs,
+		Outputs:    payload.WorkflowStep.Outputs,
+		callbackID: payload.CallbackID,
+		triggerID:  payload.TriggerID,
+		slacker:    s,
+	})
+}
+
+func (s *Slacker) handleWorkflowStepSave(payload *interaction) {
+	step, ok := s.workflowSteps[payload.View.CallbackID]
+	if !ok || step.Save == nil || payload.WorkflowStep == nil {
+		return
+	}
+
+	step.Save(&WorkflowStepSave{
+		Values:  payload.View.State.Values,
+		editID:  payload.WorkflowStep.WorkflowStepEditID,
+		slacker: s,
+	})
+}
+
+func (s *Slacker) handleWorkflowStepExecute(data json.RawMessage) {
+	event := &workflowStepExecuteEvent{}
+	err := json.Unmarshal(data, event)
+	if err != nil {
+		s.reportError(err)
+		return
+	}
+
+	step, ok := s.workflowSteps[event.CallbackID]
+	if !ok || step.Execute == nil || event.WorkflowStep == nil {
+		return
+	}
+
+	step.Execute(&WorkflowStepExecution{
+		Inputs:    event.WorkflowStep.Inputs,
+		Outputs:   event.WorkflowStep.Outputs,
+		executeID: event.WorkflowStep.WorkflowStepExecuteID,
+		slacker:   s,
+	})
+}